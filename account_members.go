@@ -0,0 +1,45 @@
+package epcc
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// AccountMembers is used to access the Account Members endpoints.
+var AccountMembers accountMembers
+
+type accountMembers struct{}
+
+// Get fetches a single account member
+func (accountMembers) Get(client *Client, accountMemberID string) (*AccountMemberData, error) {
+	path := fmt.Sprintf("/v2/account-members/%s", accountMemberID)
+
+	body, err := client.DoRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var member AccountMemberData
+	if err := json.Unmarshal(body, &member); err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+// GetAll fetches all account members
+func (accountMembers) GetAll(client *Client) (*AccountMembersData, error) {
+	path := fmt.Sprintf("/v2/account-members")
+
+	body, err := client.DoRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var members AccountMembersData
+	if err := json.Unmarshal(body, &members); err != nil {
+		return nil, err
+	}
+
+	return &members, nil
+}
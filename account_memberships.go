@@ -0,0 +1,86 @@
+package epcc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// AccountMemberships is used to access the Account Memberships endpoints.
+var AccountMemberships accountMemberships
+
+type accountMemberships struct{}
+
+// Get fetches a single account membership
+func (accountMemberships) Get(client *Client, accountID string, membershipID string) (*AccountMembershipData, error) {
+	path := fmt.Sprintf("/v2/accounts/%s/account-memberships/%s", accountID, membershipID)
+
+	body, err := client.DoRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var membership AccountMembershipData
+	if err := json.Unmarshal(body, &membership); err != nil {
+		return nil, err
+	}
+
+	return &membership, nil
+}
+
+// GetAll fetches all memberships of an account
+func (accountMemberships) GetAll(client *Client, accountID string) (*AccountMembershipsData, error) {
+	path := fmt.Sprintf("/v2/accounts/%s/account-memberships", accountID)
+
+	body, err := client.DoRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var memberships AccountMembershipsData
+	if err := json.Unmarshal(body, &memberships); err != nil {
+		return nil, err
+	}
+
+	return &memberships, nil
+}
+
+// Create adds an account member to an account
+func (accountMemberships) Create(client *Client, accountID string, accountMemberID string) (*AccountMembershipData, error) {
+	membershipData := AccountMembershipData{
+		Data: AccountMembership{
+			Type:            "account_membership",
+			AccountMemberID: accountMemberID,
+		},
+	}
+
+	jsonPayload, err := json.Marshal(membershipData)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/v2/accounts/%s/account-memberships", accountID)
+
+	body, err := client.DoRequest("POST", path, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, err
+	}
+
+	var newMembership AccountMembershipData
+	if err := json.Unmarshal(body, &newMembership); err != nil {
+		return nil, err
+	}
+
+	return &newMembership, nil
+}
+
+// Delete removes an account member from an account.
+func (accountMemberships) Delete(client *Client, accountID string, membershipID string) error {
+	path := fmt.Sprintf("/v2/accounts/%s/account-memberships/%s", accountID, membershipID)
+
+	if _, err := client.DoRequest("DELETE", path, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
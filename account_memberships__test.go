@@ -0,0 +1,103 @@
+package epcc_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func fakeHandleAccountMemberships(rw http.ResponseWriter, req *http.Request) {
+	var buffer bytes.Buffer
+	_, err := buffer.ReadFrom(req.Body)
+	if err != nil {
+		rw.WriteHeader(500)
+		return
+	}
+
+	switch {
+	case req.URL.String() == "/v2/accounts/validAccountID/account-memberships" && req.Method == "GET":
+		responseJSON := `{
+			"data":[{
+				"id":"0b5f8e7a-3e36-4b8c-b1c6-12fd2e1c3a55",
+				"type":"account_membership",
+				"relationships":{
+					"account_member":{
+						"data":{
+							"type":"account_member",
+							"id":"908f7849-60da-4e4a-a3b1-51d4cbe3b953"
+						}
+					}
+				}
+			}]
+		}`
+		rw.WriteHeader(200)
+		rw.Write([]byte(responseJSON))
+	case req.URL.String() == "/v2/accounts/validAccountID/account-memberships" && req.Method == "POST" &&
+		strings.Contains(buffer.String(), `"account_member_id":"908f7849-60da-4e4a-a3b1-51d4cbe3b953"`) &&
+		strings.Contains(buffer.String(), `"type":"account_membership"`):
+		responseJSON := `{
+			"data":{
+				"id":"0b5f8e7a-3e36-4b8c-b1c6-12fd2e1c3a55",
+				"type":"account_membership"
+			}
+		}`
+		rw.WriteHeader(201)
+		rw.Write([]byte(responseJSON))
+	case req.URL.String() == "/v2/accounts/validAccountID/account-memberships/validMembershipID" && req.Method == "DELETE":
+		rw.WriteHeader(204)
+	default:
+		rw.WriteHeader(500)
+	}
+}
+
+func TestAccountMemberships(t *testing.T) {
+	expectedMemberships := epcc.AccountMembershipsData{
+		Data: []epcc.AccountMembership{
+			{
+				ID:   "0b5f8e7a-3e36-4b8c-b1c6-12fd2e1c3a55",
+				Type: "account_membership",
+				Relationships: epcc.AccountMembershipRelationships{
+					AccountMember: epcc.RelationshipItem{
+						Data: epcc.Relationship{
+							Type: "account_member",
+							ID:   "908f7849-60da-4e4a-a3b1-51d4cbe3b953",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expectedMembership := epcc.AccountMembershipData{
+		Data: epcc.AccountMembership{
+			ID:   "0b5f8e7a-3e36-4b8c-b1c6-12fd2e1c3a55",
+			Type: "account_membership",
+		},
+	}
+
+	// Create a new client and configure it to use test server instead of the real API endpoint.
+	testServer := httptest.NewServer(http.HandlerFunc(fakeHandleAccountMemberships))
+	options := epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	}
+	client := epcc.NewClient(options)
+
+	memberships, err := epcc.AccountMemberships.GetAll(client, "validAccountID")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &expectedMemberships, memberships)
+
+	membership, err := epcc.AccountMemberships.Create(client, "validAccountID", "908f7849-60da-4e4a-a3b1-51d4cbe3b953")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &expectedMembership, membership)
+
+	err = epcc.AccountMemberships.Delete(client, "validAccountID", "validMembershipID")
+	assert.Equal(t, nil, err)
+}
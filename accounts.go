@@ -0,0 +1,109 @@
+package epcc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Accounts is used to access the Accounts endpoints.
+var Accounts accounts
+
+type accounts struct{}
+
+// Get fetches a single account
+func (accounts) Get(client *Client, accountID string) (*AccountData, error) {
+	path := fmt.Sprintf("/v2/accounts/%s", accountID)
+
+	body, err := client.DoRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var account AccountData
+	if err := json.Unmarshal(body, &account); err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+// GetAll fetches all accounts
+func (accounts) GetAll(client *Client) (*AccountsData, error) {
+	path := fmt.Sprintf("/v2/accounts")
+
+	body, err := client.DoRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts AccountsData
+	if err := json.Unmarshal(body, &accounts); err != nil {
+		return nil, err
+	}
+
+	return &accounts, nil
+}
+
+// Create creates an account
+func (accounts) Create(client *Client, account *Account) (*AccountData, error) {
+	accountData := AccountData{
+		Data: *account,
+	}
+
+	jsonPayload, err := json.Marshal(accountData)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/v2/accounts")
+
+	body, err := client.DoRequest("POST", path, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, err
+	}
+
+	var newAccount AccountData
+	if err := json.Unmarshal(body, &newAccount); err != nil {
+		return nil, err
+	}
+
+	return &newAccount, nil
+}
+
+// Update updates an account.
+func (accounts) Update(client *Client, accountID string, account *Account) (*AccountData, error) {
+	accountData := AccountData{
+		Data: *account,
+	}
+
+	jsonPayload, err := json.Marshal(accountData)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/v2/accounts/%s", accountID)
+
+	body, err := client.DoRequest("PUT", path, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedAccount AccountData
+	if err := json.Unmarshal(body, &updatedAccount); err != nil {
+		return nil, err
+	}
+
+	return &updatedAccount, nil
+}
+
+// Delete deletes an account.
+func (accounts) Delete(client *Client, accountID string) error {
+	path := fmt.Sprintf("/v2/accounts/%s", accountID)
+
+	if _, err := client.DoRequest("DELETE", path, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,369 @@
+package epcc_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func fakeHandleAccountsGet(rw http.ResponseWriter, req *http.Request) {
+	switch {
+	case req.URL.String() == "/v2/accounts/validAccountID" && req.Method == "GET":
+		responseJSON := `{
+			"data":{
+				"id":"deb6b25f-8451-4211-9a22-95610333df23",
+				"type":"account",
+				"name":"Paper Supplies Ltd",
+				"legal_name":"Paper Supplies Limited",
+				"registration_id":"00000000",
+				"links":{
+					"self":"https://api.moltin.com/v2/accounts/deb6b25f-8451-4211-9a22-95610333df23"
+				},
+				"meta":{
+					"timestamps":{
+						"created_at":"2021-02-23T09:40:33.882Z",
+						"updated_at":"2021-02-23T09:40:33.882Z"
+					}
+				}
+			}
+		}`
+
+		rw.WriteHeader(200)
+		rw.Write([]byte(responseJSON))
+	default:
+		rw.WriteHeader(500)
+	}
+}
+
+func TestAccountsGet(t *testing.T) {
+	expectedAccountData := epcc.AccountData{
+		Data: epcc.Account{
+			ID:             "deb6b25f-8451-4211-9a22-95610333df23",
+			Type:           "account",
+			Name:           "Paper Supplies Ltd",
+			LegalName:      "Paper Supplies Limited",
+			RegistrationID: "00000000",
+			Links: epcc.Links{
+				Self: "https://api.moltin.com/v2/accounts/deb6b25f-8451-4211-9a22-95610333df23",
+			},
+			Meta: epcc.AccountMeta{
+				Timestamps: epcc.Timestamps{
+					CreatedAt: "2021-02-23T09:40:33.882Z",
+					UpdatedAt: "2021-02-23T09:40:33.882Z",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		accountID   string
+		accountData epcc.AccountData
+		err         error
+	}{
+		{"validAccountID", expectedAccountData, nil},
+	}
+
+	// Create a new client and configure it to use test server instead of the real API endpoint.
+	testServer := httptest.NewServer(http.HandlerFunc(fakeHandleAccountsGet))
+	options := epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	}
+	client := epcc.NewClient(options)
+
+	for _, test := range tests {
+		accountData, err := epcc.Accounts.Get(client, test.accountID)
+		assert.Equal(t, test.accountData, *accountData)
+		assert.Equal(t, test.err, err)
+	}
+}
+
+func fakeHandleAccountsGetAll(rw http.ResponseWriter, req *http.Request) {
+	switch {
+	case req.URL.String() == "/v2/accounts" && req.Method == "GET":
+		responseJSON := `{
+			"data":[{
+				"id":"deb6b25f-8451-4211-9a22-95610333df23",
+				"type":"account",
+				"name":"Paper Supplies Ltd"
+			},{
+				"id":"96b1f750-55d3-4768-a3f2-d7a3b5b7d1f4",
+				"type":"account",
+				"name":"Paper Supplies Ltd North",
+				"parent_id":"deb6b25f-8451-4211-9a22-95610333df23",
+				"relationships":{
+					"parent":{
+						"data":{
+							"type":"account",
+							"id":"deb6b25f-8451-4211-9a22-95610333df23"
+						}
+					}
+				}
+			}]
+		}`
+
+		rw.WriteHeader(200)
+		rw.Write([]byte(responseJSON))
+	default:
+		rw.WriteHeader(500)
+	}
+}
+
+func TestAccountsGetAll(t *testing.T) {
+	expectedAccounts := epcc.AccountsData{
+		Data: []epcc.Account{
+			{
+				ID:   "deb6b25f-8451-4211-9a22-95610333df23",
+				Type: "account",
+				Name: "Paper Supplies Ltd",
+			},
+			{
+				ID:       "96b1f750-55d3-4768-a3f2-d7a3b5b7d1f4",
+				Type:     "account",
+				Name:     "Paper Supplies Ltd North",
+				ParentID: "deb6b25f-8451-4211-9a22-95610333df23",
+				Relationships: epcc.AccountRelationships{
+					Parent: epcc.RelationshipItem{
+						Data: epcc.Relationship{
+							Type: "account",
+							ID:   "deb6b25f-8451-4211-9a22-95610333df23",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		accountsData epcc.AccountsData
+		err          error
+	}{
+		{expectedAccounts, nil},
+	}
+
+	// Create a new client and configure it to use test server instead of the real API endpoint.
+	testServer := httptest.NewServer(http.HandlerFunc(fakeHandleAccountsGetAll))
+	options := epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	}
+	client := epcc.NewClient(options)
+
+	for _, test := range tests {
+		accountsData, err := epcc.Accounts.GetAll(client)
+		assert.Equal(t, test.accountsData, *accountsData)
+		assert.Equal(t, test.err, err)
+	}
+}
+
+func fakeHandleAccountsCreate(rw http.ResponseWriter, req *http.Request) {
+	var buffer bytes.Buffer
+	_, err := buffer.ReadFrom(req.Body)
+	if err != nil {
+		rw.WriteHeader(500)
+		return
+	}
+
+	switch {
+	case req.URL.String() == "/v2/accounts" && req.Method == "POST" && strings.Contains(buffer.String(), `"name":"Paper Supplies Ltd"`):
+		responseJSON := `{
+			"data":{
+				"id":"deb6b25f-8451-4211-9a22-95610333df23",
+				"type":"account",
+				"name":"Paper Supplies Ltd",
+				"legal_name":"Paper Supplies Limited"
+			}
+		}`
+
+		rw.WriteHeader(201)
+		rw.Write([]byte(responseJSON))
+	case req.URL.String() == "/v2/accounts" && req.Method == "POST":
+		responseJSON := `{
+			"errors":[{
+				"status":400,
+				"title":"Validation Error",
+				"detail":"data.name: Does not match format 'non-empty'"
+			}]
+		}`
+		rw.WriteHeader(400)
+		rw.Write([]byte(responseJSON))
+	default:
+		rw.WriteHeader(500)
+	}
+}
+
+func TestAccountsCreate(t *testing.T) {
+	validNewAccount := epcc.Account{
+		Type:      "account",
+		Name:      "Paper Supplies Ltd",
+		LegalName: "Paper Supplies Limited",
+	}
+
+	missingName := epcc.Account{
+		Type:      "account",
+		LegalName: "Paper Supplies Limited",
+	}
+
+	expectedAccountData := epcc.AccountData{
+		Data: epcc.Account{
+			ID:        "deb6b25f-8451-4211-9a22-95610333df23",
+			Type:      "account",
+			Name:      "Paper Supplies Ltd",
+			LegalName: "Paper Supplies Limited",
+		},
+	}
+
+	tests := []struct {
+		account     epcc.Account
+		accountData *epcc.AccountData
+		err         error
+	}{
+		{validNewAccount, &expectedAccountData, nil},
+		{missingName, nil, &epcc.APIError{
+			StatusCode: 400,
+			Errors: []epcc.ErrorDetail{
+				{Status: 400, Title: "Validation Error", Detail: "data.name: Does not match format 'non-empty'"},
+			},
+		}},
+	}
+
+	// Create a new client and configure it to use test server instead of the real API endpoint.
+	testServer := httptest.NewServer(http.HandlerFunc(fakeHandleAccountsCreate))
+	options := epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	}
+	client := epcc.NewClient(options)
+
+	for _, test := range tests {
+		accountData, err := epcc.Accounts.Create(client, &test.account)
+		if accountData != nil {
+			assert.Equal(t, test.accountData, accountData)
+		}
+		assert.Equal(t, test.err, err)
+	}
+}
+
+func fakeHandleAccountsUpdate(rw http.ResponseWriter, req *http.Request) {
+	var buffer bytes.Buffer
+	_, err := buffer.ReadFrom(req.Body)
+	if err != nil {
+		rw.WriteHeader(500)
+		return
+	}
+
+	switch {
+	case req.URL.String() == "/v2/accounts/validAccountID" && req.Method == "PUT" && strings.Contains(buffer.String(), `"legal_name":"Paper Supplies Group"`):
+		responseJSON := `{
+			"data":{
+				"id":"deb6b25f-8451-4211-9a22-95610333df23",
+				"type":"account",
+				"name":"Paper Supplies Ltd",
+				"legal_name":"Paper Supplies Group"
+			}
+		}`
+		rw.WriteHeader(200)
+		rw.Write([]byte(responseJSON))
+	default:
+		rw.WriteHeader(500)
+	}
+}
+
+func TestAccountsUpdate(t *testing.T) {
+	update := epcc.Account{
+		Type:      "account",
+		LegalName: "Paper Supplies Group",
+	}
+
+	expectedAccountData := epcc.AccountData{
+		Data: epcc.Account{
+			ID:        "deb6b25f-8451-4211-9a22-95610333df23",
+			Type:      "account",
+			Name:      "Paper Supplies Ltd",
+			LegalName: "Paper Supplies Group",
+		},
+	}
+
+	tests := []struct {
+		accountID   string
+		update      epcc.Account
+		accountData *epcc.AccountData
+		err         error
+	}{
+		{"validAccountID", update, &expectedAccountData, nil},
+	}
+
+	// Create a new client and configure it to use test server instead of the real API endpoint.
+	testServer := httptest.NewServer(http.HandlerFunc(fakeHandleAccountsUpdate))
+	options := epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	}
+	client := epcc.NewClient(options)
+
+	for _, test := range tests {
+		accountData, err := epcc.Accounts.Update(client, test.accountID, &test.update)
+		if accountData != nil {
+			assert.Equal(t, test.accountData, accountData)
+		}
+		assert.Equal(t, test.err, err)
+	}
+}
+
+func fakeHandleAccountsDelete(rw http.ResponseWriter, req *http.Request) {
+	switch {
+	case req.URL.String() == "/v2/accounts/validAccountID" && req.Method == "DELETE":
+		rw.WriteHeader(204)
+	case req.URL.String() == "/v2/accounts/notFound" && req.Method == "DELETE":
+		responseJSON := `{
+			"errors":[{
+				"status":404,
+				"title":"Not Found",
+				"detail":"account not found"
+			}]
+		}`
+		rw.WriteHeader(404)
+		rw.Write([]byte(responseJSON))
+	default:
+		rw.WriteHeader(500)
+	}
+}
+
+func TestAccountsDelete(t *testing.T) {
+	tests := []struct {
+		accountID string
+		err       error
+	}{
+		{"validAccountID", nil},
+		{"notFound", &epcc.APIError{
+			StatusCode: 404,
+			Errors: []epcc.ErrorDetail{
+				{Status: 404, Title: "Not Found", Detail: "account not found"},
+			},
+		}},
+	}
+
+	// Create a new client and configure it to use test server instead of the real API endpoint.
+	testServer := httptest.NewServer(http.HandlerFunc(fakeHandleAccountsDelete))
+	options := epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	}
+	client := epcc.NewClient(options)
+
+	for _, test := range tests {
+		err := epcc.Accounts.Delete(client, test.accountID)
+		assert.Equal(t, test.err, err)
+	}
+}
@@ -0,0 +1,105 @@
+package epcc
+
+// AccountData contains the data for a single account
+type AccountData struct {
+	Data Account `json:"data"`
+}
+
+// AccountsData contains the data for multiple accounts
+type AccountsData struct {
+	Data []Account `json:"data"`
+}
+
+// Account represents a B2B account
+type Account struct {
+	ID             string               `json:"id,omitempty"`
+	Type           string               `json:"type"`
+	Name           string               `json:"name,omitempty"`
+	LegalName      string               `json:"legal_name,omitempty"`
+	RegistrationID string               `json:"registration_id,omitempty"`
+	ParentID       string               `json:"parent_id,omitempty"`
+	Links          Links                `json:"links,omitempty"`
+	Meta           AccountMeta          `json:"meta,omitempty"`
+	Relationships  AccountRelationships `json:"relationships,omitempty"`
+}
+
+// AccountMeta contains extra data for an account
+type AccountMeta struct {
+	Timestamps Timestamps `json:"timestamps,omitempty"`
+}
+
+// AccountRelationships represents the relationships that can exist for an account
+type AccountRelationships struct {
+	Parent    RelationshipItem  `json:"parent,omitempty"`
+	Ancestors RelationshipItems `json:"ancestors,omitempty"`
+}
+
+// AccountMembershipData contains the data for a single account membership
+type AccountMembershipData struct {
+	Data AccountMembership `json:"data"`
+}
+
+// AccountMembershipsData contains the data for multiple account memberships
+type AccountMembershipsData struct {
+	Data []AccountMembership `json:"data"`
+}
+
+// AccountMembership links an account member to an account
+type AccountMembership struct {
+	ID              string                         `json:"id,omitempty"`
+	Type            string                         `json:"type"`
+	AccountMemberID string                         `json:"account_member_id,omitempty"`
+	Meta            AccountMeta                    `json:"meta,omitempty"`
+	Relationships   AccountMembershipRelationships `json:"relationships,omitempty"`
+}
+
+// AccountMembershipRelationships represents the relationships that can exist for an account membership
+type AccountMembershipRelationships struct {
+	AccountMember RelationshipItem `json:"account_member,omitempty"`
+}
+
+// AccountMemberData contains the data for a single account member
+type AccountMemberData struct {
+	Data AccountMember `json:"data"`
+}
+
+// AccountMembersData contains the data for multiple account members
+type AccountMembersData struct {
+	Data []AccountMember `json:"data"`
+}
+
+// AccountMember represents a user who can be a member of one or more accounts
+type AccountMember struct {
+	ID    string      `json:"id,omitempty"`
+	Type  string      `json:"type"`
+	Name  string      `json:"name,omitempty"`
+	Email string      `json:"email,omitempty"`
+	Meta  AccountMeta `json:"meta,omitempty"`
+}
+
+// accountMemberAuthRequest is the payload used to authenticate an account member
+type accountMemberAuthRequest struct {
+	Data accountMemberAuthRequestData `json:"data"`
+}
+
+type accountMemberAuthRequestData struct {
+	Type                    string `json:"type"`
+	AuthenticationMechanism string `json:"authentication_mechanism"`
+	PasswordProfileID       string `json:"password_profile_id"`
+	Username                string `json:"username"`
+	Password                string `json:"password"`
+}
+
+// AccountManagementTokensData contains the tokens issued to an authenticated account member
+type AccountManagementTokensData struct {
+	Data []AccountManagementToken `json:"data"`
+}
+
+// AccountManagementToken grants an account member access to a single account
+type AccountManagementToken struct {
+	Type        string `json:"type"`
+	AccountID   string `json:"account_id"`
+	AccountName string `json:"account_name"`
+	Token       string `json:"token"`
+	Expires     string `json:"expires"`
+}
@@ -0,0 +1,125 @@
+package epcc
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// knownAttributeNames caches the json names of the fields of each type.
+var knownAttributeNames sync.Map
+
+// attributeNames returns the set of json names declared by the fields of a struct type.
+func attributeNames(t reflect.Type) map[string]bool {
+	if names, ok := knownAttributeNames.Load(t); ok {
+		return names.(map[string]bool)
+	}
+
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		names[name] = true
+	}
+
+	knownAttributeNames.Store(t, names)
+	return names
+}
+
+// unknownAttributes returns the attributes of a json object which are not fields of the struct type of v.
+// It returns nil if every attribute is known.
+func unknownAttributes(data []byte, v interface{}) (map[string]json.RawMessage, error) {
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(data, &attributes); err != nil {
+		return nil, err
+	}
+
+	known := attributeNames(reflect.TypeOf(v))
+	for name := range attributes {
+		if known[name] {
+			delete(attributes, name)
+		}
+	}
+
+	if len(attributes) == 0 {
+		return nil, nil
+	}
+
+	return attributes, nil
+}
+
+// mergeAttributes adds extra attributes to an encoded json object.
+func mergeAttributes(data []byte, extra map[string]json.RawMessage) ([]byte, error) {
+	if len(extra) == 0 {
+		return data, nil
+	}
+
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(data, &attributes); err != nil {
+		return nil, err
+	}
+
+	for name, value := range extra {
+		if _, ok := attributes[name]; !ok {
+			attributes[name] = value
+		}
+	}
+
+	return json.Marshal(attributes)
+}
+
+// copyAttributes returns a copy of a set of attributes.
+func copyAttributes(attributes map[string]json.RawMessage) map[string]json.RawMessage {
+	if attributes == nil {
+		return nil
+	}
+
+	copied := make(map[string]json.RawMessage, len(attributes))
+	for name, value := range attributes {
+		copied[name] = append(json.RawMessage(nil), value...)
+	}
+	return copied
+}
+
+// getAttribute decodes a single attribute into v, reporting whether the attribute exists.
+func getAttribute(attributes map[string]json.RawMessage, name string, v interface{}) (bool, error) {
+	raw, ok := attributes[name]
+	if !ok {
+		return false, nil
+	}
+
+	if err := json.Unmarshal(raw, v); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// setAttribute encodes v as an attribute, refusing names which are fields of the struct type of owner.
+func setAttribute(attributes map[string]json.RawMessage, owner interface{}, name string, v interface{}) (map[string]json.RawMessage, error) {
+	if attributeNames(reflect.TypeOf(owner))[name] {
+		return attributes, fmt.Errorf("error %s is not an unknown attribute", name)
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return attributes, err
+	}
+
+	if attributes == nil {
+		attributes = make(map[string]json.RawMessage)
+	}
+	attributes[name] = raw
+	return attributes, nil
+}
@@ -2,12 +2,14 @@ package epcc
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 type authResponse struct {
@@ -18,34 +20,65 @@ type authResponse struct {
 	AccessToken string `json:"access_token"`
 }
 
-//auth returns an AccessToken or an Error
-func auth(client Client) (string, error) {
+//auth returns an AccessToken and when it expires or an Error
+func auth(ctx context.Context, client *Client) (token string, expires time.Time, err error) {
 	reqURL, err := url.Parse(client.BaseURL)
 
 	reqURL.Path = fmt.Sprintf("/oauth/access_token")
 
+	// Credentials set on the client are used before the environment variables.
+	clientID, clientSecret := client.clientID, client.clientSecret
+	if clientID == "" && clientSecret == "" {
+		clientID, clientSecret = cfg.Credentials.ClientID, cfg.Credentials.ClientSecret
+	}
+	if clientID == "" || clientSecret == "" {
+		return "", time.Time{}, errors.New("error client id and client secret are required, set GO_EPCC_CLIENT_ID and GO_EPCC_CLIENT_SECRET")
+	}
+
 	values := url.Values{}
-	values.Set("client_id", cfg.Credentials.ClientID)
-	values.Set("client_secret", cfg.Credentials.ClientSecret)
+	values.Set("client_id", clientID)
+	values.Set("client_secret", clientSecret)
 	values.Set("grant_type", "client_credentials")
 
 	body := strings.NewReader(values.Encode())
 
-	req, err := http.NewRequest("POST", reqURL.String(), body)
+	// Authentication is traced and measured as a single attempt.
+	route := reqURL.Path
+	ctx, span := client.startSpan(ctx, SpanAuthenticate, Attribute{AttributeMethod, "POST"}, Attribute{AttributeRoute, route})
+	status := 0
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		if status != 0 {
+			span.SetAttributes(Attribute{AttributeStatus, status})
+		}
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+		client.recordAttempt(AttemptMetrics{Method: "POST", Endpoint: route, Status: status, Attempt: 1, Duration: duration})
+		client.recordRequest(RequestMetrics{Method: "POST", Endpoint: route, Status: status, Attempts: 1, Duration: duration, Err: err})
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", reqURL.String(), body)
 	if err != nil {
-		return "", err
+		return "", time.Time{}, err
 	}
 
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := client.HTTPClient.Do(req)
+	resp, err := client.do(req)
+	fields := []LogField{{"method", "POST"}, {"path", reqURL.Path}, {"duration", time.Since(start)}}
 	if err != nil {
-		return "", err
+		client.log(LevelError, "authentication failed", append(fields, LogField{"error", err})...)
+		return "", time.Time{}, err
 	}
+	status = resp.StatusCode
 
 	if resp.StatusCode != 200 {
-		return "", fmt.Errorf("error: unexpected status %s", resp.Status)
+		client.log(LevelError, "authentication failed", append(fields, LogField{"status", resp.StatusCode})...)
+		return "", time.Time{}, fmt.Errorf("error: unexpected status %s", resp.Status)
 	}
 
 	var buffer bytes.Buffer
@@ -54,9 +87,47 @@ func auth(client Client) (string, error) {
 
 	var authResponse authResponse
 	if err := json.Unmarshal(buffer.Bytes(), &authResponse); err != nil {
-		return "", err
+		return "", time.Time{}, err
+	}
+
+	// The expiry is taken from expires_in when it is sent, so a clock which differs from the API's does not matter.
+	switch {
+	case authResponse.ExpiresIn > 0:
+		expires = start.Add(time.Duration(authResponse.ExpiresIn) * time.Second)
+	case authResponse.Expires > 0:
+		expires = time.Unix(int64(authResponse.Expires), 0)
+	}
+
+	client.log(LevelInfo, "authentication successful", append(fields, LogField{"status", resp.StatusCode})...)
+	return authResponse.AccessToken, expires, nil
+}
+
+// accountMemberAuth returns the account management tokens issued to an account member or an Error
+func accountMemberAuth(client *Client, passwordProfileID string, username string, password string) ([]AccountManagementToken, error) {
+	authRequest := accountMemberAuthRequest{
+		Data: accountMemberAuthRequestData{
+			Type:                    "account_management_authentication_token",
+			AuthenticationMechanism: "password",
+			PasswordProfileID:       passwordProfileID,
+			Username:                username,
+			Password:                password,
+		},
+	}
+
+	jsonPayload, err := json.Marshal(authRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := client.DoRequest("POST", "/v2/account-members/tokens", bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens AccountManagementTokensData
+	if err := json.Unmarshal(body, &tokens); err != nil {
+		return nil, err
 	}
 
-	log.Println("authentication successful")
-	return authResponse.AccessToken, nil
+	return tokens.Data, nil
 }
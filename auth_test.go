@@ -2,6 +2,7 @@ package epcc
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -69,8 +70,37 @@ func TestAuth(t *testing.T) {
 		cfg.Credentials.ClientID = test.clientID
 		cfg.Credentials.ClientSecret = test.clientSecret
 
-		token, err := auth(*client)
+		token, _, err := auth(context.Background(), client)
 		assert.Equal(t, test.expectedToken, token)
 		assert.Equal(t, test.err, err)
 	}
 }
+
+func TestAuthClientCredentials(t *testing.T) {
+	// Create a new client and configure it to use test server instead of the real API endpoint.
+	testServer := httptest.NewServer(http.HandlerFunc(fakeHandleAuth))
+	options := ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+		ClientID:          "validClientID",
+		ClientSecret:      "validClientSecret",
+	}
+
+	// Credentials on the client are used instead of the environment.
+	cfg.Credentials.ClientID = "invalidClientID"
+	cfg.Credentials.ClientSecret = "invalidClientSecret"
+
+	token, _, err := auth(context.Background(), NewClient(options))
+	assert.Equal(t, "f64e7f07b10f710a15e4f41d670f0d7d7d4e415d", token)
+	assert.Equal(t, nil, err)
+
+	cfg.Credentials.ClientID = ""
+	cfg.Credentials.ClientSecret = ""
+	options.ClientID = ""
+	options.ClientSecret = ""
+
+	token, _, err = auth(context.Background(), NewClient(options))
+	assert.Equal(t, "", token)
+	assert.Equal(t, errors.New("error client id and client secret are required, set GO_EPCC_CLIENT_ID and GO_EPCC_CLIENT_SECRET"), err)
+}
@@ -0,0 +1,604 @@
+package epcc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Catalogue is the desired state of a store's currencies and products,
+// currencies are keyed by code and products are keyed by SKU.
+//
+// Only the kinds of resource a catalogue manages are planned, a catalogue file
+// which has no currencies section leaves the store's currencies alone.
+// Fields which are empty are not compared unless they are set in the catalogue file.
+type Catalogue struct {
+	Currencies        []Currency
+	Products          []Product
+	ManagesCurrencies bool
+	ManagesProducts   bool
+	fileFields        map[string]map[string]json.RawMessage
+}
+
+// catalogueFile is the layout of a catalogue file, resources use the field names of the API.
+type catalogueFile struct {
+	Currencies []json.RawMessage `json:"currencies"`
+	Products   []json.RawMessage `json:"products"`
+}
+
+// LoadCatalogue reads a catalogue from a YAML or JSON file.
+func LoadCatalogue(path string) (*Catalogue, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCatalogue(data)
+}
+
+// ParseCatalogue parses a catalogue from YAML or JSON.
+func ParseCatalogue(data []byte) (*Catalogue, error) {
+	jsonData, err := toJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal(jsonData, &sections); err != nil {
+		return nil, err
+	}
+
+	var file catalogueFile
+	if err := json.Unmarshal(jsonData, &file); err != nil {
+		return nil, err
+	}
+
+	catalogue := Catalogue{
+		ManagesCurrencies: sections["currencies"] != nil,
+		ManagesProducts:   sections["products"] != nil,
+		fileFields:        map[string]map[string]json.RawMessage{},
+	}
+
+	for i, raw := range file.Currencies {
+		var currency Currency
+		if err := json.Unmarshal(raw, &currency); err != nil {
+			return nil, fmt.Errorf("error in currency %d: %w", i, err)
+		}
+		if currency.Type == "" {
+			currency.Type = "currency"
+		}
+		catalogue.Currencies = append(catalogue.Currencies, currency)
+		catalogue.fileFields["currency:"+currency.Code] = objectFields(raw)
+	}
+
+	for i, raw := range file.Products {
+		var product Product
+		if err := json.Unmarshal(raw, &product); err != nil {
+			return nil, fmt.Errorf("error in product %d: %w", i, err)
+		}
+		if product.Type == "" {
+			product.Type = "product"
+		}
+		catalogue.Products = append(catalogue.Products, product)
+		catalogue.fileFields["product:"+product.SKU] = objectFields(raw)
+	}
+
+	if err := catalogue.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &catalogue, nil
+}
+
+// toJSON converts YAML to JSON, JSON is returned as it is.
+func toJSON(data []byte) ([]byte, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		return trimmed, nil
+	}
+
+	var decoded interface{}
+	if err := yaml.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	if decoded == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(decoded)
+}
+
+// objectFields returns the fields of a JSON object.
+func objectFields(raw json.RawMessage) map[string]json.RawMessage {
+	var fields map[string]json.RawMessage
+	json.Unmarshal(raw, &fields)
+	return fields
+}
+
+// Validate checks every currency has a code and every product has a SKU, and that none are repeated.
+func (c Catalogue) Validate() error {
+	codes := map[string]bool{}
+	for i, currency := range c.Currencies {
+		if currency.Code == "" {
+			return fmt.Errorf("error currency %d has no code", i)
+		}
+		if codes[currency.Code] {
+			return fmt.Errorf("error currency %s is defined more than once", currency.Code)
+		}
+		codes[currency.Code] = true
+	}
+
+	skus := map[string]bool{}
+	for i, product := range c.Products {
+		if product.SKU == "" {
+			return fmt.Errorf("error product %d has no sku", i)
+		}
+		if skus[product.SKU] {
+			return fmt.Errorf("error product %s is defined more than once", product.SKU)
+		}
+		skus[product.SKU] = true
+	}
+
+	return nil
+}
+
+// ChangeAction is what applying a plan does to a resource.
+type ChangeAction string
+
+// Actions in a plan.
+const (
+	ChangeCreate ChangeAction = "create"
+	ChangeUpdate ChangeAction = "update"
+	ChangeDelete ChangeAction = "delete"
+	ChangeNoOp   ChangeAction = "no-op"
+)
+
+// Change is a planned change to a single currency or product.
+// Kind is either "currency" or "product", Key is its code or SKU and ID is the ID of the live resource.
+type Change struct {
+	Action ChangeAction
+	Kind   string
+	Key    string
+	ID     string
+	Diffs  []FieldDiff
+
+	currency *Currency
+	product  *Product
+	fields   map[string]json.RawMessage
+}
+
+// FieldDiff is a field which is changed, Old and New are JSON values.
+// Old is empty when the resource is created.
+type FieldDiff struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// Plan is the list of changes which make a store match a catalogue.
+// Currencies are created and updated before products, and deleted after them.
+type Plan struct {
+	Changes []Change
+}
+
+// ApplyOptions configures how a catalogue is planned.
+type ApplyOptions struct {
+	Prune bool // Prune deletes the resources which are not in the catalogue.
+}
+
+// PlanCatalogue compares a catalogue with the live store and plans the changes to make them match.
+func PlanCatalogue(client *Client, catalogue *Catalogue, options ApplyOptions) (*Plan, error) {
+	if err := catalogue.Validate(); err != nil {
+		return nil, err
+	}
+	if err := checkProductEnums(client, catalogue.Products...); err != nil {
+		return nil, err
+	}
+
+	var plan Plan
+	var deletes []Change
+
+	if catalogue.ManagesCurrencies {
+		live, err := Currencies.GetAll(client)
+		if err != nil {
+			return nil, err
+		}
+
+		liveByCode := map[string]Currency{}
+		for _, currency := range live.Data {
+			liveByCode[currency.Code] = currency
+		}
+
+		for i := range catalogue.Currencies {
+			desired := catalogue.Currencies[i]
+			change := Change{Kind: "currency", Key: desired.Code}
+
+			current, ok := liveByCode[desired.Code]
+			delete(liveByCode, desired.Code)
+
+			if err := planChange(&change, current, ok, desired, catalogue.fields("currency:"+desired.Code)); err != nil {
+				return nil, err
+			}
+			change.ID = current.ID
+			if change.Action == ChangeUpdate {
+				merged := Currency{}
+				fields, err := mergeFields(current, desired, catalogue.fields("currency:"+desired.Code), change.Diffs, &merged)
+				if err != nil {
+					return nil, err
+				}
+				change.currency = &merged
+				change.fields = fields
+			} else {
+				change.currency = &desired
+			}
+			plan.Changes = append(plan.Changes, change)
+		}
+
+		// The default currency cannot be deleted, so it is never pruned.
+		for _, currency := range sortedCurrencies(liveByCode) {
+			if options.Prune && !currency.Default {
+				deletes = append(deletes, Change{Action: ChangeDelete, Kind: "currency", Key: currency.Code, ID: currency.ID})
+			}
+		}
+	}
+
+	if catalogue.ManagesProducts {
+		live, err := Products.GetAll(client)
+		if err != nil {
+			return nil, err
+		}
+
+		liveBySKU := map[string]Product{}
+		for _, product := range live.Data {
+			liveBySKU[product.SKU] = product
+		}
+
+		for i := range catalogue.Products {
+			desired := catalogue.Products[i]
+			change := Change{Kind: "product", Key: desired.SKU}
+
+			current, ok := liveBySKU[desired.SKU]
+			delete(liveBySKU, desired.SKU)
+
+			if err := planChange(&change, current, ok, desired, catalogue.fields("product:"+desired.SKU)); err != nil {
+				return nil, err
+			}
+			change.ID = current.ID
+			if change.Action == ChangeUpdate {
+				merged := Product{}
+				fields, err := mergeFields(current, desired, catalogue.fields("product:"+desired.SKU), change.Diffs, &merged)
+				if err != nil {
+					return nil, err
+				}
+				merged.ID = current.ID
+				change.product = &merged
+				change.fields = fields
+			} else {
+				change.product = &desired
+			}
+			plan.Changes = append(plan.Changes, change)
+		}
+
+		// Products are deleted before currencies.
+		var productDeletes []Change
+		for _, product := range sortedProducts(liveBySKU) {
+			if options.Prune {
+				productDeletes = append(productDeletes, Change{Action: ChangeDelete, Kind: "product", Key: product.SKU, ID: product.ID})
+			}
+		}
+		deletes = append(productDeletes, deletes...)
+	}
+
+	plan.Changes = append(plan.Changes, deletes...)
+	return &plan, nil
+}
+
+// fields returns the fields set in the catalogue file for a resource.
+func (c Catalogue) fields(key string) map[string]json.RawMessage {
+	return c.fileFields[key]
+}
+
+// ignoredFields are not compared because they are read only or identify the resource.
+var ignoredFields = map[string]bool{
+	"id":            true,
+	"type":          true,
+	"links":         true,
+	"meta":          true,
+	"relationships": true,
+}
+
+// planChange sets the action and diffs of a change to make current match desired.
+func planChange(change *Change, current interface{}, exists bool, desired interface{}, file map[string]json.RawMessage) error {
+	desiredFields, err := withFileFields(desired, file)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		change.Action = ChangeCreate
+		for _, field := range sortedKeys(desiredFields) {
+			value := desiredFields[field]
+			if _, set := file[field]; ignoredFields[field] || (isEmptyJSON(value) && !set) {
+				continue
+			}
+			change.Diffs = append(change.Diffs, FieldDiff{Field: field, New: compactJSON(value)})
+		}
+		return nil
+	}
+
+	currentFields, err := jsonFields(current)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range sortedKeys(desiredFields) {
+		value := desiredFields[field]
+		if _, set := file[field]; ignoredFields[field] || (isEmptyJSON(value) && !set) {
+			continue
+		}
+		// A field missing from current was left out of its encoding because it is empty.
+		if _, ok := currentFields[field]; !ok && isEmptyJSON(value) {
+			continue
+		}
+		if !equalJSON(currentFields[field], value) {
+			change.Diffs = append(change.Diffs, FieldDiff{
+				Field: field,
+				Old:   compactJSON(currentFields[field]),
+				New:   compactJSON(value),
+			})
+		}
+	}
+
+	change.Action = ChangeNoOp
+	if len(change.Diffs) > 0 {
+		change.Action = ChangeUpdate
+	}
+	return nil
+}
+
+// mergeFields decodes current with the changed fields of desired into merged and returns the merged fields.
+// The fields are the payload of the update, unlike merged they keep the false and zero values set in the catalogue file.
+func mergeFields(current interface{}, desired interface{}, file map[string]json.RawMessage, diffs []FieldDiff, merged interface{}) (map[string]json.RawMessage, error) {
+	currentFields, err := jsonFields(current)
+	if err != nil {
+		return nil, err
+	}
+	desiredFields, err := withFileFields(desired, file)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, diff := range diffs {
+		currentFields[diff.Field] = desiredFields[diff.Field]
+	}
+	for field := range ignoredFields {
+		if field != "id" && field != "type" {
+			delete(currentFields, field)
+		}
+	}
+
+	data, err := json.Marshal(currentFields)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, merged); err != nil {
+		return nil, err
+	}
+	return currentFields, nil
+}
+
+// withFileFields encodes desired and adds the fields set in the catalogue file which the encoding leaves out,
+// so a field set to false or zero with omitempty is still compared and sent.
+func withFileFields(desired interface{}, file map[string]json.RawMessage) (map[string]json.RawMessage, error) {
+	fields, err := jsonFields(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	for field, value := range file {
+		if _, ok := fields[field]; !ok {
+			fields[field] = value
+		}
+	}
+	return fields, nil
+}
+
+// jsonFields encodes v and returns its fields.
+func jsonFields(v interface{}) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// isEmptyJSON reports whether a JSON value is null, false, zero or empty.
+func isEmptyJSON(value json.RawMessage) bool {
+	var decoded interface{}
+	if err := json.Unmarshal(value, &decoded); err != nil {
+		return true
+	}
+	switch v := decoded.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// equalJSON reports whether two JSON values are equal, ignoring formatting and the order of object fields.
+func equalJSON(a json.RawMessage, b json.RawMessage) bool {
+	var decodedA, decodedB interface{}
+	if len(a) > 0 {
+		json.Unmarshal(a, &decodedA)
+	}
+	if len(b) > 0 {
+		json.Unmarshal(b, &decodedB)
+	}
+	return reflect.DeepEqual(decodedA, decodedB)
+}
+
+// compactJSON returns a JSON value on a single line.
+func compactJSON(value json.RawMessage) string {
+	if len(value) == 0 {
+		return "null"
+	}
+	var buffer bytes.Buffer
+	if err := json.Compact(&buffer, value); err != nil {
+		return string(value)
+	}
+	return buffer.String()
+}
+
+func sortedKeys(fields map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedCurrencies(currencies map[string]Currency) []Currency {
+	sorted := make([]Currency, 0, len(currencies))
+	for _, currency := range currencies {
+		sorted = append(sorted, currency)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Code < sorted[j].Code })
+	return sorted
+}
+
+func sortedProducts(products map[string]Product) []Product {
+	sorted := make([]Product, 0, len(products))
+	for _, product := range products {
+		sorted = append(sorted, product)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].SKU < sorted[j].SKU })
+	return sorted
+}
+
+// HasChanges reports whether applying the plan changes the store.
+func (p Plan) HasChanges() bool {
+	for _, change := range p.Changes {
+		if change.Action != ChangeNoOp {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a human readable diff of the plan.
+func (p Plan) String() string {
+	var b strings.Builder
+	counts := map[ChangeAction]int{}
+
+	for _, change := range p.Changes {
+		counts[change.Action]++
+
+		switch change.Action {
+		case ChangeCreate:
+			fmt.Fprintf(&b, "+ %s %s\n", change.Kind, change.Key)
+			for _, diff := range change.Diffs {
+				fmt.Fprintf(&b, "    %s: %s\n", diff.Field, diff.New)
+			}
+		case ChangeUpdate:
+			fmt.Fprintf(&b, "~ %s %s\n", change.Kind, change.Key)
+			for _, diff := range change.Diffs {
+				fmt.Fprintf(&b, "    %s: %s -> %s\n", diff.Field, diff.Old, diff.New)
+			}
+		case ChangeDelete:
+			fmt.Fprintf(&b, "- %s %s\n", change.Kind, change.Key)
+		}
+	}
+
+	fmt.Fprintf(&b, "Plan: %d to create, %d to update, %d to delete, %d unchanged.\n",
+		counts[ChangeCreate], counts[ChangeUpdate], counts[ChangeDelete], counts[ChangeNoOp])
+	return b.String()
+}
+
+// Apply makes the changes in the plan in order, stopping at the first change which fails.
+// The IDs of created resources are recorded in the plan.
+func (p *Plan) Apply(client *Client) error {
+	for i := range p.Changes {
+		if err := p.Changes[i].apply(client); err != nil {
+			return fmt.Errorf("error applying %s of %s %s: %w", p.Changes[i].Action, p.Changes[i].Kind, p.Changes[i].Key, err)
+		}
+	}
+	return nil
+}
+
+func (c *Change) apply(client *Client) error {
+	switch {
+	case c.Action == ChangeNoOp:
+		return nil
+
+	case c.Kind == "currency" && c.Action == ChangeCreate:
+		created, err := Currencies.Create(client, c.currency)
+		if err != nil {
+			return err
+		}
+		c.ID = created.Data.ID
+		return nil
+
+	case c.Kind == "currency" && c.Action == ChangeUpdate:
+		if err := validatePayload(client, c.currency.ValidateUpdate); err != nil {
+			return err
+		}
+		return updateFields(client, fmt.Sprintf("/v2/currencies/%s", c.ID), c.fields)
+
+	case c.Kind == "currency" && c.Action == ChangeDelete:
+		return Currencies.Delete(client, c.ID)
+
+	case c.Kind == "product" && c.Action == ChangeCreate:
+		created, err := Products.Create(client, c.product)
+		if err != nil {
+			return err
+		}
+		c.ID = created.Data.ID
+		return nil
+
+	case c.Kind == "product" && c.Action == ChangeUpdate:
+		if err := validatePayload(client, c.product.ValidateUpdate); err != nil {
+			return err
+		}
+		if err := checkProductEnums(client, *c.product); err != nil {
+			return err
+		}
+		return updateFields(client, fmt.Sprintf("/v2/products/%s", c.ID), c.fields)
+
+	case c.Kind == "product" && c.Action == ChangeDelete:
+		return Products.Delete(client, c.ID)
+	}
+
+	return errors.New("error unknown change")
+}
+
+// updateFields sends the merged fields of an update, the typed resource would leave out the fields set to false or zero.
+func updateFields(client *Client, path string, fields map[string]json.RawMessage) error {
+	jsonPayload, err := json.Marshal(map[string]interface{}{"data": fields})
+	if err != nil {
+		return err
+	}
+
+	_, err = client.DoRequest("PUT", path, bytes.NewBuffer(jsonPayload))
+	return err
+}
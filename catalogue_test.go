@@ -0,0 +1,346 @@
+package epcc_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/Rosalita/go-epcc-client/epcctest"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeStore keeps currencies and products in memory and records every change made to it.
+type fakeStore struct {
+	mu         sync.Mutex
+	currencies map[string]epcc.Currency
+	products   map[string]epcc.Product
+	nextID     int
+	requests   []string
+	payloads   map[string]string
+}
+
+func (f *fakeStore) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] != "v2" {
+		rw.WriteHeader(500)
+		return
+	}
+	resource := parts[1]
+	id := ""
+	if len(parts) == 3 {
+		id = parts[2]
+	}
+	if req.Method != "GET" {
+		f.requests = append(f.requests, req.Method+" "+req.URL.Path)
+		body, _ := ioutil.ReadAll(req.Body)
+		f.payloads[req.Method+" "+req.URL.Path] = string(body)
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	switch {
+	case resource == "currencies" && req.Method == "GET":
+		data := []epcc.Currency{}
+		for _, currency := range f.currencies {
+			data = append(data, currency)
+		}
+		json.NewEncoder(rw).Encode(epcc.CurrenciesData{Data: data})
+	case resource == "products" && req.Method == "GET":
+		data := []epcc.Product{}
+		for _, product := range f.products {
+			data = append(data, product)
+		}
+		json.NewEncoder(rw).Encode(epcc.ProductsData{Data: data})
+	case resource == "currencies" && (req.Method == "POST" || req.Method == "PUT"):
+		var currencyData epcc.CurrencyData
+		json.NewDecoder(req.Body).Decode(&currencyData)
+		if id == "" {
+			f.nextID++
+			id = fmt.Sprintf("currency-%d", f.nextID)
+		}
+		currencyData.Data.ID = id
+		f.currencies[id] = currencyData.Data
+		json.NewEncoder(rw).Encode(currencyData)
+	case resource == "products" && (req.Method == "POST" || req.Method == "PUT"):
+		var productData epcc.ProductData
+		json.NewDecoder(req.Body).Decode(&productData)
+		if id == "" {
+			f.nextID++
+			id = fmt.Sprintf("product-%d", f.nextID)
+		}
+		productData.Data.ID = id
+		f.products[id] = productData.Data
+		json.NewEncoder(rw).Encode(productData)
+	case resource == "currencies" && req.Method == "DELETE":
+		delete(f.currencies, id)
+		rw.WriteHeader(204)
+	case resource == "products" && req.Method == "DELETE":
+		delete(f.products, id)
+		rw.WriteHeader(204)
+	default:
+		rw.WriteHeader(500)
+	}
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		payloads: map[string]string{},
+		currencies: map[string]epcc.Currency{
+			"gbp": {Type: "currency", ID: "gbp", Code: "GBP", ExchangeRate: 1, Format: "£{price}", DecimalPoint: ".", DecimalPlaces: 2, Default: true, Enabled: true},
+			"eur": {Type: "currency", ID: "eur", Code: "EUR", ExchangeRate: 1.1, Format: "€{price}", DecimalPoint: ",", DecimalPlaces: 2, Enabled: true},
+			"jpy": {Type: "currency", ID: "jpy", Code: "JPY", ExchangeRate: 140, Format: "¥{price}", Enabled: true},
+		},
+		products: map[string]epcc.Product{
+			"frog": {Type: "product", ID: "frog", SKU: "FRG", Name: "Origami Frog", Slug: "frog", Status: "live", CommodityType: "physical",
+				Price: []epcc.ProductPrice{{Amount: 100, Currency: "GBP"}}},
+			"cat": {Type: "product", ID: "cat", SKU: "CAT", Name: "Origami Cat", Slug: "cat", Status: "live", CommodityType: "physical",
+				Description: "A cat", Price: []epcc.ProductPrice{{Amount: 200, Currency: "GBP"}}},
+			"old": {Type: "product", ID: "old", SKU: "OLD", Name: "Old", Slug: "old", Status: "draft", CommodityType: "physical"},
+		},
+	}
+}
+
+const catalogueYAML = `
+currencies:
+  - code: GBP
+    exchange_rate: 1
+    format: "£{price}"
+    decimal_point: "."
+    decimal_places: 2
+    default: true
+    enabled: true
+  - code: EUR
+    exchange_rate: 1.2
+    format: "€{price}"
+    decimal_point: ","
+    decimal_places: 2
+    enabled: true
+products:
+  - sku: FRG
+    name: Origami Frog
+    slug: frog
+    status: live
+    commodity_type: physical
+    price:
+      - amount: 100
+        currency: GBP
+  - sku: CAT
+    name: Origami Cat
+    slug: cat
+    description: ""
+    status: live
+    commodity_type: physical
+    price:
+      - amount: 250
+        currency: GBP
+  - sku: CRN
+    name: Origami Crane
+    slug: crane
+    status: draft
+    commodity_type: physical
+`
+
+func TestParseCatalogue(t *testing.T) {
+	catalogue, err := epcc.ParseCatalogue([]byte(catalogueYAML))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, catalogue.ManagesCurrencies)
+	assert.Equal(t, true, catalogue.ManagesProducts)
+	assert.Equal(t, 2, len(catalogue.Currencies))
+	assert.Equal(t, "currency", catalogue.Currencies[1].Type)
+	assert.Equal(t, 1.2, catalogue.Currencies[1].ExchangeRate)
+	assert.Equal(t, "product", catalogue.Products[0].Type)
+	assert.Equal(t, []epcc.ProductPrice{{Amount: 100, Currency: "GBP"}}, catalogue.Products[0].Price)
+
+	jsonCatalogue, err := epcc.ParseCatalogue([]byte(`{"products":[{"sku":"FRG","name":"Origami Frog"}]}`))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, jsonCatalogue.ManagesCurrencies)
+	assert.Equal(t, "Origami Frog", jsonCatalogue.Products[0].Name)
+
+	tests := []struct {
+		catalogue string
+		err       string
+	}{
+		{"products:\n  - name: No SKU\n", "error product 0 has no sku"},
+		{"products:\n  - sku: FRG\n  - sku: FRG\n", "error product FRG is defined more than once"},
+		{"currencies:\n  - format: \"{price}\"\n", "error currency 0 has no code"},
+		{"currencies:\n  - code: GBP\n  - code: GBP\n", "error currency GBP is defined more than once"},
+	}
+
+	for _, test := range tests {
+		_, err := epcc.ParseCatalogue([]byte(test.catalogue))
+		assert.Equal(t, test.err, err.Error())
+	}
+}
+
+func TestPlanCatalogue(t *testing.T) {
+	store := newFakeStore()
+
+	// Create a new client and configure it to use test server instead of the real API endpoint.
+	testServer := httptest.NewServer(store)
+	options := epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	}
+	client := epcc.NewClient(options)
+
+	catalogue, err := epcc.ParseCatalogue([]byte(catalogueYAML))
+	assert.Equal(t, nil, err)
+
+	plan, err := epcc.PlanCatalogue(client, catalogue, epcc.ApplyOptions{Prune: true})
+	assert.Equal(t, nil, err)
+
+	expected := `~ currency EUR
+    exchange_rate: 1.1 -> 1.2
+~ product CAT
+    description: "A cat" -> ""
+    price: [{"amount":200,"currency":"GBP","includes_tax":false}] -> [{"amount":250,"currency":"GBP","includes_tax":false}]
++ product CRN
+    commodity_type: "physical"
+    name: "Origami Crane"
+    sku: "CRN"
+    slug: "crane"
+    status: "draft"
+- product OLD
+- currency JPY
+Plan: 1 to create, 2 to update, 2 to delete, 2 unchanged.
+`
+	assert.Equal(t, expected, plan.String())
+	assert.Equal(t, true, plan.HasChanges())
+
+	// Nothing is changed until the plan is applied.
+	assert.Equal(t, 0, len(store.requests))
+
+	err = plan.Apply(client)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{
+		"PUT /v2/currencies/eur",
+		"PUT /v2/products/cat",
+		"POST /v2/products",
+		"DELETE /v2/products/old",
+		"DELETE /v2/currencies/jpy",
+	}, store.requests)
+
+	assert.Equal(t, 1.2, store.currencies["eur"].ExchangeRate)
+	assert.Equal(t, "", store.products["cat"].Description)
+	assert.Equal(t, 250, store.products["cat"].Price[0].Amount)
+	assert.Equal(t, "Origami Cat", store.products["cat"].Name)
+	assert.Equal(t, "product-1", plan.Changes[4].ID)
+
+	// Once applied the store matches the catalogue.
+	plan, err = epcc.PlanCatalogue(client, catalogue, epcc.ApplyOptions{Prune: true})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, plan.HasChanges())
+	assert.Equal(t, "Plan: 0 to create, 0 to update, 0 to delete, 5 unchanged.\n", plan.String())
+}
+
+func TestPlanCatalogueWithoutPrune(t *testing.T) {
+	store := newFakeStore()
+
+	// Create a new client and configure it to use test server instead of the real API endpoint.
+	testServer := httptest.NewServer(store)
+	options := epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	}
+	client := epcc.NewClient(options)
+
+	// Empty fields which are not in the file are not compared and currencies are not managed.
+	catalogue, err := epcc.ParseCatalogue([]byte("products:\n  - sku: CAT\n    name: Origami Cat\n"))
+	assert.Equal(t, nil, err)
+
+	plan, err := epcc.PlanCatalogue(client, catalogue, epcc.ApplyOptions{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, plan.HasChanges())
+	assert.Equal(t, []epcc.Change{{Action: epcc.ChangeNoOp, Kind: "product", Key: "CAT", ID: "cat"}}, stripChanges(plan.Changes))
+}
+
+func TestPlanCatalogueFalseField(t *testing.T) {
+	store := newFakeStore()
+
+	// Create a new client and configure it to use test server instead of the real API endpoint.
+	testServer := httptest.NewServer(store)
+	options := epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	}
+	client := epcc.NewClient(options)
+
+	// Enabled is false, which the encoding of a currency leaves out, it is still planned and sent.
+	catalogue, err := epcc.ParseCatalogue([]byte("currencies:\n  - code: EUR\n    enabled: false\n"))
+	assert.Equal(t, nil, err)
+
+	plan, err := epcc.PlanCatalogue(client, catalogue, epcc.ApplyOptions{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []epcc.Change{{Action: epcc.ChangeUpdate, Kind: "currency", Key: "EUR", ID: "eur",
+		Diffs: []epcc.FieldDiff{{Field: "enabled", Old: "true", New: "false"}}}}, stripChanges(plan.Changes))
+
+	err = plan.Apply(client)
+	assert.Equal(t, nil, err)
+
+	var payload struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	err = json.Unmarshal([]byte(store.payloads["PUT /v2/currencies/eur"]), &payload)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "false", string(payload.Data["enabled"]))
+	assert.Equal(t, "\"EUR\"", string(payload.Data["code"]))
+	assert.Equal(t, false, store.currencies["eur"].Enabled)
+
+	plan, err = epcc.PlanCatalogue(client, catalogue, epcc.ApplyOptions{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, plan.HasChanges())
+}
+
+func TestPlanCataloguePages(t *testing.T) {
+	server := epcctest.NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	// More products and currencies than fit on the first page of a list.
+	var last epcc.Product
+	var lastCurrency epcc.Currency
+	for i := 0; i < 30; i++ {
+		last = server.AddProduct(epcc.Product{Name: fmt.Sprintf("Origami %d", i), Slug: fmt.Sprintf("origami-%d", i), SKU: fmt.Sprintf("SKU-%d", i), Status: "live"})
+		lastCurrency = server.AddCurrency(epcc.Currency{Code: fmt.Sprintf("C%02d", i), Format: "{price}", Enabled: true})
+	}
+
+	catalogue, err := epcc.ParseCatalogue([]byte("currencies:\n  - code: C29\nproducts:\n  - sku: SKU-29\n    name: Origami 29\n"))
+	assert.Equal(t, nil, err)
+
+	plan, err := epcc.PlanCatalogue(client, catalogue, epcc.ApplyOptions{Prune: true})
+	assert.Equal(t, nil, err)
+
+	counts := map[epcc.ChangeAction]int{}
+	for _, change := range plan.Changes {
+		counts[change.Action]++
+		if change.Action == epcc.ChangeNoOp && change.Kind == "product" {
+			assert.Equal(t, last.ID, change.ID)
+		}
+		if change.Action == epcc.ChangeNoOp && change.Kind == "currency" {
+			assert.Equal(t, lastCurrency.ID, change.ID)
+		}
+	}
+	assert.Equal(t, map[epcc.ChangeAction]int{epcc.ChangeNoOp: 2, epcc.ChangeDelete: 58}, counts)
+}
+
+// stripChanges drops the unexported payloads of changes so they can be compared.
+func stripChanges(changes []epcc.Change) []epcc.Change {
+	stripped := make([]epcc.Change, len(changes))
+	for i, change := range changes {
+		stripped[i] = epcc.Change{Action: change.Action, Kind: change.Kind, Key: change.Key, ID: change.ID, Diffs: change.Diffs}
+	}
+	return stripped
+}
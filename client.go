@@ -2,30 +2,56 @@ package epcc
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"log"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"gopkg.in/retry.v1"
 )
 
 // Client is the type used to interface with EPCC API.
+// Each client has its own credentials and tokens, so clients for different stores can be used at once.
+// A client is safe for concurrent use once it is configured.
 type Client struct {
-	BaseURL       string
-	HTTPClient    *http.Client
-	RetryStrategy retry.Strategy
-	accessToken   string
+	BaseURL          string
+	HTTPClient       *http.Client
+	RetryStrategy    retry.Strategy
+	StrictEnums      bool
+	ValidatePayloads bool
+	Middleware       []Middleware    // Middleware wraps every request the client sends, see Use.
+	Logger           Logger          // Logger receives the client's log entries, nothing is logged when it is nil.
+	Tracer           Tracer          // Tracer starts a span for each request, attempt and authentication.
+	Metrics          MetricsRecorder // Metrics receives the metrics of each request and attempt.
+	clientID         string
+	clientSecret     string
+	mu               sync.RWMutex // mu guards the tokens.
+	authMu           sync.Mutex   // authMu is held while the client authenticates again.
+	accessToken      string
+	tokenExpires     time.Time // tokenExpires is when the access token expires, zero when it is not known.
+	accountToken     string
+	accountTokens    []AccountManagementToken
 }
 
 // ClientOptions can be used to configure a new client.
 type ClientOptions struct {
-	BaseURL           string        // BaseURL is the where requests will be made to.
-	ClientTimeout     time.Duration // ClientTimeout is how long the client waits for a response before timing out.
-	RetryLimitTimeout time.Duration // RetryLimitTimeout is how long requests will be retried for status codes 429, 500, 503 & 504
+	BaseURL           string          // BaseURL is the where requests will be made to.
+	ClientTimeout     time.Duration   // ClientTimeout is how long the client waits for a response before timing out.
+	RetryLimitTimeout time.Duration   // RetryLimitTimeout is how long requests will be retried for status codes 429, 500, 503 & 504
+	StrictEnums       bool            // StrictEnums rejects unknown product statuses, commodity types and stock availabilities.
+	ValidatePayloads  bool            // ValidatePayloads validates products and currencies before they are created or updated.
+	ClientID          string          // ClientID is used to authenticate instead of GO_EPCC_CLIENT_ID when set.
+	ClientSecret      string          // ClientSecret is used to authenticate instead of GO_EPCC_CLIENT_SECRET when set.
+	Middleware        []Middleware    // Middleware wraps every request the client sends.
+	Logger            Logger          // Logger receives the client's log entries, nothing is logged when it is nil.
+	Tracer            Tracer          // Tracer starts a span for each request, attempt and authentication.
+	Metrics           MetricsRecorder // Metrics receives the metrics of each request and attempt.
 }
 
 // NewClient creates a new instance of a Client.
@@ -60,7 +86,15 @@ func NewClient(options ...ClientOptions) *Client {
 				HTTPClient: &http.Client{
 					Timeout: options[i].ClientTimeout,
 				},
-				RetryStrategy: strategy,
+				RetryStrategy:    strategy,
+				StrictEnums:      options[i].StrictEnums,
+				ValidatePayloads: options[i].ValidatePayloads,
+				Middleware:       options[i].Middleware,
+				Logger:           options[i].Logger,
+				Tracer:           options[i].Tracer,
+				Metrics:          options[i].Metrics,
+				clientID:         options[i].ClientID,
+				clientSecret:     options[i].ClientSecret,
 			}
 			return &customClient
 		}
@@ -69,45 +103,218 @@ func NewClient(options ...ClientOptions) *Client {
 	return nil
 }
 
-//Authenticate attempts to generate an access token and save it on the client.
+// AccessToken returns the access token saved by Authenticate.
+func (c *Client) AccessToken() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.accessToken
+}
+
+// Authenticate attempts to generate an access token and save it on the client.
 func (c *Client) Authenticate() error {
-	token, err := auth(*c)
+	return c.AuthenticateContext(context.Background())
+}
+
+// AuthenticateContext attempts to generate an access token and save it on the client.
+// The context is passed to the client's tracer and middleware.
+//
+// Once authenticated, the client keeps itself authenticated. The access token is generated again
+// before a request when it has expired, and when the API rejects it with 401 Unauthorized.
+func (c *Client) AuthenticateContext(ctx context.Context) error {
+	token, expires, err := auth(ctx, c)
 	if err != nil {
 		return err
 	}
 
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.accessToken = token
+	c.tokenExpires = expires
 	return nil
 }
 
+// reauthenticate replaces an access token which expired or was rejected, and returns the new token.
+// When several requests find the same stale token, only the first authenticates and the others use its token.
+func (c *Client) reauthenticate(ctx context.Context, stale string, reason string) (string, error) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	if token := c.AccessToken(); token != stale {
+		return token, nil
+	}
+
+	c.log(LevelInfo, "authenticating again", LogField{"reason", reason})
+	if err := c.AuthenticateContext(ctx); err != nil {
+		return "", err
+	}
+	return c.AccessToken(), nil
+}
+
+// AuthenticateAccountMember attempts to generate account management authentication tokens for an account member.
+// If the member belongs to exactly one account, that account is selected automatically.
+func (c *Client) AuthenticateAccountMember(passwordProfileID string, username string, password string) ([]AccountManagementToken, error) {
+	tokens, err := accountMemberAuth(c, passwordProfileID, username, password)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.accountTokens = tokens
+	c.accountToken = ""
+	if len(tokens) == 1 {
+		c.accountToken = tokens[0].Token
+	}
+
+	return tokens, nil
+}
+
+// SelectAccount scopes subsequent requests, such as carts and orders, to one of the accounts
+// the authenticated account member belongs to.
+func (c *Client) SelectAccount(accountID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, token := range c.accountTokens {
+		if token.AccountID == accountID {
+			c.accountToken = token.Token
+			return nil
+		}
+	}
+
+	return fmt.Errorf("error no account management token for account %s", accountID)
+}
+
+// SetAccountManagementToken scopes subsequent requests using an existing account management authentication token.
+// An empty token removes the account scope.
+func (c *Client) SetAccountManagementToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.accountToken = token
+}
+
 // DoRequest makes a html request to the EPCC API and handles the response.
 func (c *Client) DoRequest(method string, path string, payload io.Reader) (body []byte, err error) {
+	return c.DoRequestContext(context.Background(), method, path, payload)
+}
+
+// DoRequestContext makes a html request to the EPCC API with a context and handles the response.
+// The context is passed to the client's middleware, such as a request ID for RequestIDMiddleware.
+// When the context has no request ID, one is generated so every attempt of the request shares it.
+func (c *Client) DoRequestContext(ctx context.Context, method string, path string, payload io.Reader) (body []byte, err error) {
+	if RequestIDFromContext(ctx) == "" {
+		requestID, err := newRequestID()
+		if err != nil {
+			return nil, err
+		}
+		ctx = ContextWithRequestID(ctx, requestID)
+	}
+
 	reqURL, err := url.Parse(c.BaseURL)
 	if err != nil {
 		return nil, err
 	}
 
-	reqURL.Path = path
-
-	req, err := http.NewRequest(method, reqURL.String(), payload)
+	// The path may include a query string, such as a filter.
+	pathURL, err := url.Parse(path)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.accessToken))
-	req.Header.Add("Content-Type", "application/json")
+	reqURL.Path = pathURL.Path
+	reqURL.RawQuery = pathURL.RawQuery
+
+	// The payload is read once so the same body can be sent by every attempt.
+	var payloadBytes []byte
+	if payload != nil {
+		if payloadBytes, err = ioutil.ReadAll(payload); err != nil {
+			return nil, err
+		}
+	}
+
+	c.mu.RLock()
+	accessToken, accountToken, tokenExpires := c.accessToken, c.accountToken, c.tokenExpires
+	c.mu.RUnlock()
+
+	// Each request is traced and measured once it finishes, however it returns.
+	route := endpoint(reqURL.Path)
+	ctx, span := c.startSpan(ctx, SpanRequest, Attribute{AttributeMethod, method}, Attribute{AttributeRoute, route})
+	requestStart := time.Now()
+	status, attempt := 0, 0
+	defer func() {
+		if status != 0 {
+			span.SetAttributes(Attribute{AttributeStatus, status})
+		}
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+		c.recordRequest(RequestMetrics{Method: method, Endpoint: route, Status: status, Attempts: attempt, Duration: time.Since(requestStart), Err: err})
+	}()
+
+	// A client which authenticated is authenticated again once its token expires.
+	if accessToken != "" && !tokenExpires.IsZero() && !time.Now().Before(tokenExpires) {
+		if accessToken, err = c.reauthenticate(ctx, accessToken, "expired"); err != nil {
+			return nil, err
+		}
+	}
 
+	reauthenticated := false
 	for r := retry.Start(c.RetryStrategy, nil); r.Next(); {
-		resp, err := c.HTTPClient.Do(req)
+		attempt++
+		var reqBody io.Reader
+		if payload != nil {
+			reqBody = bytes.NewReader(payloadBytes)
+		}
 
+		attemptCtx, attemptSpan := c.startSpan(ctx, SpanAttempt, Attribute{AttributeAttempt, attempt})
+		req, err := http.NewRequestWithContext(attemptCtx, method, reqURL.String(), reqBody)
 		if err != nil {
+			attemptSpan.End()
+			return nil, err
+		}
+
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+		req.Header.Add("Content-Type", "application/json")
+		if accountToken != "" {
+			req.Header.Add("EP-Account-Management-Authentication-Token", accountToken)
+		}
+
+		start := time.Now()
+		resp, err := c.do(req)
+		duration := time.Since(start)
+		fields := []LogField{{"method", method}, {"path", reqURL.Path}, {"attempt", attempt}, {"duration", duration}}
+
+		if err != nil {
+			c.log(LevelError, "request failed", append(fields, LogField{"error", err})...)
+			attemptSpan.RecordError(err)
+			attemptSpan.End()
+			c.recordAttempt(AttemptMetrics{Method: method, Endpoint: route, Attempt: attempt, Duration: duration})
 			return nil, err
 		}
 		defer resp.Body.Close()
+		fields = append(fields, LogField{"status", resp.StatusCode})
+
+		status = resp.StatusCode
+		retried := status == 429 || status == 500 || status == 503 || status == 504
+		attemptSpan.SetAttributes(Attribute{AttributeStatus, status})
+		attemptSpan.End()
+		c.recordAttempt(AttemptMetrics{Method: method, Endpoint: route, Status: status, Attempt: attempt, Retried: retried, Duration: duration})
+
+		// A rejected token is replaced once, then the request is sent again with its own retries.
+		if status == 401 && accessToken != "" && !reauthenticated {
+			io.Copy(ioutil.Discard, resp.Body)
+			if accessToken, err = c.reauthenticate(ctx, accessToken, "unauthorized"); err != nil {
+				return nil, err
+			}
+			reauthenticated = true
+			r = retry.Start(c.RetryStrategy, nil)
+			continue
+		}
 
 		switch resp.StatusCode {
 		case 429, 500, 503, 504:
-			log.Printf("Response Status %d Retrying request", resp.StatusCode)
+			c.log(LevelWarn, "retrying request", fields...)
+			io.Copy(ioutil.Discard, resp.Body)
 			continue
 
 		case 200, 201:
@@ -116,9 +323,11 @@ func (c *Client) DoRequest(method string, path string, payload io.Reader) (body
 				return nil, err
 			}
 
+			c.log(LevelDebug, "request succeeded", fields...)
 			return buffer.Bytes(), nil
 
 		case 204:
+			c.log(LevelDebug, "request succeeded", fields...)
 			return nil, nil
 
 		default:
@@ -127,13 +336,18 @@ func (c *Client) DoRequest(method string, path string, payload io.Reader) (body
 				return nil, err
 			}
 
-			log.Printf("response: %s", buffer.String())
-			err := fmt.Errorf("status code %d is not ok", resp.StatusCode)
+			c.log(LevelWarn, "response is not ok", append(fields, LogField{"body", buffer.String()})...)
 
-			return nil, err
+			// The body is decoded when possible, the status code is always reported.
+			apiError := APIError{}
+			json.Unmarshal(buffer.Bytes(), &apiError)
+			apiError.StatusCode = resp.StatusCode
+
+			return nil, &apiError
 		}
 	}
 
+	c.log(LevelError, "retry timeout", LogField{"method", method}, LogField{"path", reqURL.Path}, LogField{"attempt", attempt})
 	err = errors.New("retry timeout error")
 	return nil, err
 }
@@ -1,9 +1,12 @@
 package epcc
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -52,3 +55,161 @@ func TestAuthenticate(t *testing.T) {
 		assert.Equal(t, test.err, err)
 	}
 }
+
+func fakeHandleAccountMemberAuth(rw http.ResponseWriter, req *http.Request) {
+	var buffer bytes.Buffer
+	_, err := buffer.ReadFrom(req.Body)
+	if err != nil {
+		rw.WriteHeader(500)
+		return
+	}
+
+	switch {
+	case req.URL.String() == "/v2/account-members/tokens" && req.Method == "POST" &&
+		strings.Contains(buffer.String(), `"username":"validUsername"`) &&
+		strings.Contains(buffer.String(), `"authentication_mechanism":"password"`):
+		responseJSON := `{
+			"data":[{
+				"type":"account_management_authentication_token",
+				"account_id":"deb6b25f-8451-4211-9a22-95610333df23",
+				"account_name":"Paper Supplies Ltd",
+				"token":"firstAccountToken",
+				"expires":"2021-03-02T10:31:43.001Z"
+			},{
+				"type":"account_management_authentication_token",
+				"account_id":"96b1f750-55d3-4768-a3f2-d7a3b5b7d1f4",
+				"account_name":"Paper Supplies Ltd North",
+				"token":"secondAccountToken",
+				"expires":"2021-03-02T10:31:43.001Z"
+			}]
+		}`
+		rw.WriteHeader(201)
+		rw.Write([]byte(responseJSON))
+	case req.URL.String() == "/v2/carts/accountCart" && req.Method == "GET":
+		rw.WriteHeader(200)
+		rw.Write([]byte(req.Header.Get("EP-Account-Management-Authentication-Token")))
+	default:
+		rw.WriteHeader(401)
+	}
+}
+
+func TestAuthenticateAccountMember(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(fakeHandleAccountMemberAuth))
+	options := ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	}
+	client := NewClient(options)
+
+	_, err := client.AuthenticateAccountMember("profileID", "invalidUsername", "password")
+	assert.Equal(t, &APIError{StatusCode: 401}, err)
+
+	tokens, err := client.AuthenticateAccountMember("profileID", "validUsername", "password")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(tokens))
+
+	// With more than one account, no account is selected until one is chosen.
+	body, err := client.DoRequest("GET", "/v2/carts/accountCart", nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "", string(body))
+
+	err = client.SelectAccount("96b1f750-55d3-4768-a3f2-d7a3b5b7d1f4")
+	assert.Equal(t, nil, err)
+	body, err = client.DoRequest("GET", "/v2/carts/accountCart", nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "secondAccountToken", string(body))
+
+	err = client.SelectAccount("unknownAccountID")
+	assert.Equal(t, errors.New("error no account management token for account unknownAccountID"), err)
+}
+
+func TestDoRequestRetrySendsPayload(t *testing.T) {
+	var bodies []string
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var buffer bytes.Buffer
+		buffer.ReadFrom(req.Body)
+		bodies = append(bodies, buffer.String())
+
+		if len(bodies) == 1 {
+			rw.WriteHeader(503)
+			return
+		}
+		rw.WriteHeader(201)
+		rw.Write([]byte(`{"data":{}}`))
+	}))
+	options := ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: time.Second,
+	}
+	client := NewClient(options)
+
+	body, err := client.DoRequest("POST", "/v2/products", strings.NewReader(`{"data":{"sku":"FRG"}}`))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{"data":{}}`, string(body))
+	assert.Equal(t, []string{`{"data":{"sku":"FRG"}}`, `{"data":{"sku":"FRG"}}`}, bodies)
+}
+
+// fakeTokenServer issues a new token each time a client authenticates and only accepts the latest one.
+type fakeTokenServer struct {
+	issued []string
+	valid  string
+}
+
+func (s *fakeTokenServer) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/oauth/access_token" {
+		token := fmt.Sprintf("token-%d", len(s.issued)+1)
+		s.issued = append(s.issued, token)
+		s.valid = token
+		rw.WriteHeader(200)
+		rw.Write([]byte(`{"access_token":"` + token + `","expires_in":3600}`))
+		return
+	}
+
+	if req.Header.Get("Authorization") != "Bearer "+s.valid {
+		rw.WriteHeader(401)
+		return
+	}
+	rw.WriteHeader(200)
+	rw.Write([]byte(`{"data":[]}`))
+}
+
+func TestClientAuthenticatesAgain(t *testing.T) {
+	tokens := &fakeTokenServer{}
+	testServer := httptest.NewServer(tokens)
+	defer testServer.Close()
+	options := ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+		ClientID:          "clientID",
+		ClientSecret:      "clientSecret",
+	}
+
+	// A client which never authenticated does not start to.
+	_, err := NewClient(options).DoRequest("GET", "/v2/products", nil)
+	assert.Equal(t, &APIError{StatusCode: 401}, err)
+	assert.Equal(t, 0, len(tokens.issued))
+
+	client := NewClient(options)
+	before := time.Now()
+	assert.Equal(t, nil, client.Authenticate())
+	assert.Equal(t, true, client.tokenExpires.After(before.Add(59*time.Minute)))
+
+	// An expired token is replaced before the request is sent.
+	client.mu.Lock()
+	client.tokenExpires = time.Now().Add(-time.Second)
+	client.mu.Unlock()
+	_, err = client.DoRequest("GET", "/v2/products", nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"token-1", "token-2"}, tokens.issued)
+	assert.Equal(t, "token-2", client.AccessToken())
+
+	// A rejected token is replaced and the request is sent again.
+	tokens.valid = ""
+	_, err = client.DoRequest("GET", "/v2/products", nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"token-1", "token-2", "token-3"}, tokens.issued)
+	assert.Equal(t, "token-3", client.AccessToken())
+}
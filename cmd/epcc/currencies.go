@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/Rosalita/go-epcc-client"
+)
+
+func currenciesList(c *cli, args []string) error {
+	flags := flag.NewFlagSet("currencies list", flag.ContinueOnError)
+	if err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+
+	currencies, err := epcc.Currencies.GetAll(c.client)
+	if err != nil {
+		return err
+	}
+	return c.printList(currencies.Data, currencyColumns)
+}
+
+func currenciesGet(c *cli, args []string) error {
+	flags := flag.NewFlagSet("currencies get", flag.ContinueOnError)
+	if err := parseArgs(flags, args, 1); err != nil {
+		return err
+	}
+
+	currency, err := epcc.Currencies.Get(c.client, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	return c.printItem(currency.Data, currencyColumns)
+}
+
+func currenciesCreate(c *cli, args []string) error {
+	flags := flag.NewFlagSet("currencies create", flag.ContinueOnError)
+	file := flags.String("f", "", "the JSON or YAML file to read, - is stdin")
+	if err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+
+	var currency epcc.Currency
+	if err := c.readInput(*file, &currency); err != nil {
+		return err
+	}
+	if currency.Type == "" {
+		currency.Type = "currency"
+	}
+
+	created, err := epcc.Currencies.Create(c.client, &currency)
+	if err != nil {
+		return err
+	}
+	return c.printItem(created.Data, currencyColumns)
+}
+
+func currenciesUpdate(c *cli, args []string) error {
+	flags := flag.NewFlagSet("currencies update", flag.ContinueOnError)
+	file := flags.String("f", "", "the JSON or YAML file to read, - is stdin")
+	if err := parseArgs(flags, args, 1); err != nil {
+		return err
+	}
+
+	var currency epcc.Currency
+	if err := c.readInput(*file, &currency); err != nil {
+		return err
+	}
+	if currency.Type == "" {
+		currency.Type = "currency"
+	}
+
+	updated, err := epcc.Currencies.Update(c.client, flags.Arg(0), &currency)
+	if err != nil {
+		return err
+	}
+	return c.printItem(updated.Data, currencyColumns)
+}
+
+func currenciesDelete(c *cli, args []string) error {
+	flags := flag.NewFlagSet("currencies delete", flag.ContinueOnError)
+	if err := parseArgs(flags, args, 1); err != nil {
+		return err
+	}
+
+	return epcc.Currencies.Delete(c.client, flags.Arg(0))
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// parseArgs parses the flags of a subcommand and checks it was given the expected number of arguments.
+func parseArgs(flags *flag.FlagSet, args []string, expected int) error {
+	flags.SetOutput(ioutil.Discard)
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+	if flags.NArg() != expected {
+		return errUsage
+	}
+	return nil
+}
+
+// readInput decodes a resource from a JSON or YAML file into v, stdin is read when path is "" or "-".
+// The resource may be given on its own or wrapped in data, as it is sent to the API.
+func (c *cli) readInput(path string, v interface{}) error {
+	var r io.Reader = c.stdin
+	if path != "" && path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		r = file
+	}
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		return errors.New("error no input, give a file with -f or write to stdin")
+	}
+
+	// YAML is a superset of JSON, so both are decoded as YAML then encoded as JSON.
+	var decoded interface{}
+	if err := yaml.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if fields, ok := decoded.(map[string]interface{}); ok && len(fields) == 1 && fields["data"] != nil {
+		decoded = fields["data"]
+	}
+
+	jsonData, err := json.Marshal(decoded)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonData, v)
+}
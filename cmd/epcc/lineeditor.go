@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// errInterrupt is returned by ReadLine when ctrl-c is pressed.
+var errInterrupt = errors.New("interrupt")
+
+// Keys read by the line editor.
+const (
+	keyCtrlA     = 1
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlE     = 5
+	keyBackspace = 8
+	keyTab       = 9
+	keyLineFeed  = 10
+	keyEnter     = 13
+	keyCtrlU     = 21
+	keyEscape    = 27
+	keyDelete    = 127
+)
+
+// completer returns the words which complete the word being typed at the end of line.
+type completer func(line string) []string
+
+// lineEditor reads lines from a terminal in raw mode, with history and tab completion.
+type lineEditor struct {
+	in       *bufio.Reader
+	out      io.Writer
+	complete completer
+	history  []string
+
+	line     []rune
+	pos      int
+	tabbed   bool
+	browsing int
+}
+
+func newLineEditor(in io.Reader, out io.Writer, complete completer) *lineEditor {
+	return &lineEditor{in: bufio.NewReader(in), out: out, complete: complete}
+}
+
+// ReadLine shows the prompt and returns the line typed, io.EOF is returned when ctrl-d is pressed on an empty line.
+func (e *lineEditor) ReadLine(prompt string) (string, error) {
+	e.line = e.line[:0]
+	e.pos = 0
+	e.browsing = len(e.history)
+	e.tabbed = false
+	fmt.Fprint(e.out, prompt)
+
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		tabbed := false
+		switch r {
+		case keyEnter, keyLineFeed:
+			fmt.Fprint(e.out, "\r\n")
+			line := string(e.line)
+			if strings.TrimSpace(line) != "" && (len(e.history) == 0 || e.history[len(e.history)-1] != line) {
+				e.history = append(e.history, line)
+			}
+			return line, nil
+		case keyCtrlC:
+			fmt.Fprint(e.out, "^C\r\n")
+			return "", errInterrupt
+		case keyCtrlD:
+			if len(e.line) == 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return "", io.EOF
+			}
+		case keyBackspace, keyDelete:
+			if e.pos > 0 {
+				e.line = append(e.line[:e.pos-1], e.line[e.pos:]...)
+				e.pos--
+			}
+		case keyCtrlA:
+			e.pos = 0
+		case keyCtrlE:
+			e.pos = len(e.line)
+		case keyCtrlU:
+			e.line = e.line[:0]
+			e.pos = 0
+		case keyTab:
+			tabbed = e.tab(prompt)
+		case keyEscape:
+			e.escape()
+		default:
+			if r >= ' ' {
+				e.line = append(e.line, 0)
+				copy(e.line[e.pos+1:], e.line[e.pos:])
+				e.line[e.pos] = r
+				e.pos++
+			}
+		}
+		e.tabbed = tabbed
+		e.redraw(prompt)
+	}
+}
+
+// escape handles the arrow keys, which are sent as ESC [ A to ESC [ D.
+func (e *lineEditor) escape() {
+	if b, _ := e.in.ReadByte(); b != '[' {
+		return
+	}
+	b, _ := e.in.ReadByte()
+	switch b {
+	case 'A':
+		if e.browsing > 0 {
+			e.browsing--
+			e.line = []rune(e.history[e.browsing])
+			e.pos = len(e.line)
+		}
+	case 'B':
+		if e.browsing < len(e.history) {
+			e.browsing++
+			e.line = e.line[:0]
+			if e.browsing < len(e.history) {
+				e.line = []rune(e.history[e.browsing])
+			}
+			e.pos = len(e.line)
+		}
+	case 'C':
+		if e.pos < len(e.line) {
+			e.pos++
+		}
+	case 'D':
+		if e.pos > 0 {
+			e.pos--
+		}
+	}
+}
+
+// tab completes the word before the cursor. When there is more than one completion the
+// common prefix is added, and pressing tab again lists them. It reports whether a list was possible.
+func (e *lineEditor) tab(prompt string) bool {
+	if e.complete == nil {
+		return false
+	}
+
+	before := string(e.line[:e.pos])
+	candidates := e.complete(before)
+	if len(candidates) == 0 {
+		return false
+	}
+
+	word := before[strings.LastIndex(before, " ")+1:]
+	prefix := commonPrefix(candidates)
+	if len(candidates) == 1 {
+		prefix += " "
+	}
+
+	if len(prefix) > len(word) {
+		e.insert([]rune(prefix[len(word):]))
+		return false
+	}
+
+	if e.tabbed {
+		fmt.Fprintf(e.out, "\r\n%s\r\n", strings.Join(candidates, "  "))
+	}
+	return true
+}
+
+func (e *lineEditor) insert(runes []rune) {
+	line := make([]rune, 0, len(e.line)+len(runes))
+	line = append(line, e.line[:e.pos]...)
+	line = append(line, runes...)
+	line = append(line, e.line[e.pos:]...)
+	e.line = line
+	e.pos += len(runes)
+}
+
+// redraw writes the prompt and line again and moves the cursor to its position.
+func (e *lineEditor) redraw(prompt string) {
+	fmt.Fprintf(e.out, "\r\x1b[K%s%s", prompt, string(e.line))
+	if back := len(e.line) - e.pos; back > 0 {
+		fmt.Fprintf(e.out, "\x1b[%dD", back)
+	}
+}
+
+// commonPrefix returns the longest prefix shared by every word.
+func commonPrefix(words []string) string {
+	prefix := words[0]
+	for _, word := range words[1:] {
+		for !strings.HasPrefix(word, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+// plainLineReader reads lines when the terminal cannot be put in raw mode, such as when input is piped.
+type plainLineReader struct {
+	in      *bufio.Scanner
+	out     io.Writer
+	history []string
+}
+
+func (p *plainLineReader) ReadLine(prompt string) (string, error) {
+	fmt.Fprint(p.out, prompt)
+	if !p.in.Scan() {
+		if err := p.in.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	line := p.in.Text()
+	if strings.TrimSpace(line) != "" {
+		p.history = append(p.history, line)
+	}
+	return line, nil
+}
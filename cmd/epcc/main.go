@@ -0,0 +1,212 @@
+// Command epcc manages the products and currencies of an EPCC store from the shell.
+//
+// Usage:
+//
+//	epcc [global flags] <command> <subcommand> [flags] [arguments]
+//
+// Commands:
+//
+//	products list|get|create|update|delete
+//	currencies list|get|create|update|delete
+//	auth token
+//	shell
+//
+// Credentials are read from a profile in the config file, or from GO_EPCC_CLIENT_ID and GO_EPCC_CLIENT_SECRET.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Rosalita/go-epcc-client"
+)
+
+const usage = `usage: epcc [global flags] <command> <subcommand> [flags] [arguments]
+
+commands:
+  products list
+  products get <id>
+  products create [-f file]
+  products update <id> [-f file]
+  products delete <id>
+  currencies list
+  currencies get <id>
+  currencies create [-f file]
+  currencies update <id> [-f file]
+  currencies delete <id>
+  auth token
+  shell
+
+Input is read as JSON or YAML from the file given by -f, or from stdin when -f is - or not set.
+Results are written as JSON unless another --output format is chosen.
+
+global flags:
+`
+
+// defaultBaseURL is used when neither the profile nor --base-url set a base URL.
+const defaultBaseURL = "https://api.moltin.com/"
+
+// errUsage is returned when a command is used incorrectly, usage is printed instead of the error.
+var errUsage = errors.New("usage")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// cli is the state shared by every command.
+type cli struct {
+	client *epcc.Client
+	output output
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	// resource and ids are used by the shell to complete the IDs of resources it has printed.
+	resource string
+	ids      map[string][]string
+}
+
+// command runs a subcommand with its remaining arguments.
+type command func(c *cli, args []string) error
+
+// commands are the subcommands of each command.
+var commands = map[string]map[string]command{
+	"products": {
+		"list":   productsList,
+		"get":    productsGet,
+		"create": productsCreate,
+		"update": productsUpdate,
+		"delete": productsDelete,
+	},
+	"currencies": {
+		"list":   currenciesList,
+		"get":    currenciesGet,
+		"create": currenciesCreate,
+		"update": currenciesUpdate,
+		"delete": currenciesDelete,
+	},
+	"auth": {
+		"token": authToken,
+	},
+}
+
+// run runs the command line and returns the exit code.
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("epcc", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+
+	baseURL := flags.String("base-url", "", "the API base URL, overrides the profile")
+	profileName := flags.String("profile", os.Getenv("EPCC_PROFILE"), "the credential profile to use")
+	configPath := flags.String("config", defaultConfigPath(), "the config file holding credential profiles")
+	verbose := flags.Bool("verbose", false, "log requests to stderr")
+	outputFormat := flags.String("output", outputJSON, "the output format: table, json, ndjson, csv or template")
+	columns := flags.String("columns", "", "the comma separated columns of table and csv output, such as id,name,price.amount,meta.stock.level")
+	outputTemplate := flags.String("template", "", "the Go template executed for each resource with --output template, such as {{.Name}}")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	shell := flags.NArg() == 1 && flags.Arg(0) == "shell"
+	if !shell && (flags.NArg() < 2 || commands[flags.Arg(0)] == nil || commands[flags.Arg(0)][flags.Arg(1)] == nil) {
+		flags.Usage()
+		return 2
+	}
+
+	out := output{format: *outputFormat, columns: *columns, template: *outputTemplate}
+	if err := out.validate(); err != nil {
+		fmt.Fprintf(stderr, "epcc: %s\n", err)
+		return 2
+	}
+
+	profile, err := loadProfile(*configPath, *profileName)
+	if err != nil {
+		fmt.Fprintf(stderr, "epcc: %s\n", err)
+		return 1
+	}
+	if *baseURL != "" {
+		profile.BaseURL = *baseURL
+	}
+	if profile.BaseURL == "" {
+		profile.BaseURL = defaultBaseURL
+	}
+
+	client := epcc.NewClient(epcc.ClientOptions{
+		BaseURL:           profile.BaseURL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 30 * time.Second,
+		ClientID:          profile.ClientID,
+		ClientSecret:      profile.ClientSecret,
+	})
+	if *verbose {
+		client.Logger = epcc.NewStdLogger(log.New(stderr, "epcc: ", 0), epcc.LevelDebug)
+	}
+
+	c := &cli{client: client, output: out, stdin: stdin, stdout: stdout, stderr: stderr}
+
+	if err := client.Authenticate(); err != nil {
+		return c.fail(err)
+	}
+
+	if shell {
+		return c.shell()
+	}
+
+	cmd := commands[flags.Arg(0)][flags.Arg(1)]
+	if err := cmd(c, flags.Args()[2:]); err != nil {
+		if err == errUsage {
+			flags.Usage()
+			return 2
+		}
+		return c.fail(err)
+	}
+	return 0
+}
+
+// fail prints an error and returns the exit code, errors from the API are printed with the errors they hold.
+func (c *cli) fail(err error) int {
+	fmt.Fprintf(c.stderr, "epcc: %s\n", err)
+
+	var apiError *epcc.APIError
+	var validationError *epcc.ValidationError
+	switch {
+	case errors.As(err, &apiError) && len(apiError.Errors) > 0:
+		c.printErrors(apiError.Errors)
+	case errors.As(err, &validationError):
+		c.printErrors(validationError.Errors)
+	}
+	return 1
+}
+
+func (c *cli) printErrors(details []epcc.ErrorDetail) {
+	encoder := json.NewEncoder(c.stderr)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(struct {
+		Errors []epcc.ErrorDetail `json:"errors"`
+	}{details})
+}
+
+// print writes v to stdout as indented JSON.
+func (c *cli) print(v interface{}) error {
+	encoder := json.NewEncoder(c.stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func authToken(c *cli, args []string) error {
+	if len(args) != 0 {
+		return errUsage
+	}
+	_, err := fmt.Fprintln(c.stdout, c.client.AccessToken())
+	return err
+}
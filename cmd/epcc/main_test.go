@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fakeHandleCLI(rw http.ResponseWriter, req *http.Request) {
+	var buffer bytes.Buffer
+	buffer.ReadFrom(req.Body)
+
+	if req.URL.Path == "/oauth/access_token" {
+		if !strings.Contains(buffer.String(), "client_id=profileClientID") {
+			rw.WriteHeader(401)
+			return
+		}
+		rw.WriteHeader(200)
+		rw.Write([]byte(`{"access_token":"profileToken","token_type":"Bearer"}`))
+		return
+	}
+
+	if req.Header.Get("Authorization") != "Bearer profileToken" {
+		rw.WriteHeader(401)
+		return
+	}
+
+	switch {
+	case req.URL.String() == "/v2/products" && req.Method == "GET":
+		rw.WriteHeader(200)
+		rw.Write([]byte(`{"data":[{"type":"product","id":"frog","name":"Origami Frog","sku":"FRG"}]}`))
+	case req.URL.String() == "/v2/products?filter=eq%28sku%2CFRG%29" && req.Method == "GET":
+		rw.WriteHeader(200)
+		rw.Write([]byte(`{"data":[{"type":"product","id":"frog","name":"Filtered Frog","sku":"FRG"}]}`))
+	case req.URL.String() == "/v2/products" && req.Method == "POST":
+		var product struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		json.Unmarshal(buffer.Bytes(), &product)
+		product.Data["id"] = "crane"
+		rw.WriteHeader(201)
+		json.NewEncoder(rw).Encode(product)
+	case req.URL.String() == "/v2/currencies/missing" && req.Method == "GET":
+		rw.WriteHeader(404)
+		rw.Write([]byte(`{"errors":[{"status":404,"title":"Currency not found","detail":"The requested currency could not be found"}]}`))
+	case req.URL.String() == "/v2/currencies/gbp" && req.Method == "DELETE":
+		rw.WriteHeader(204)
+	default:
+		rw.WriteHeader(500)
+	}
+}
+
+func TestRun(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(fakeHandleCLI))
+	defer testServer.Close()
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	config := "default: test\nprofiles:\n  test:\n    base_url: " + testServer.URL + "\n    client_id: profileClientID\n    client_secret: profileClientSecret\n  other:\n    client_id: otherClientID\n    client_secret: otherClientSecret\n"
+	assert.Equal(t, nil, ioutil.WriteFile(configPath, []byte(config), 0600))
+
+	productFile := filepath.Join(t.TempDir(), "crane.yaml")
+	assert.Equal(t, nil, ioutil.WriteFile(productFile, []byte("name: Origami Crane\nsku: CRN\n"), 0600))
+
+	tests := []struct {
+		args   []string
+		stdin  string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{
+			args:   []string{"auth", "token"},
+			stdout: "profileToken\n",
+		},
+		{
+			args:   []string{"products", "list"},
+			stdout: `"name": "Origami Frog"`,
+		},
+		{
+			args:   []string{"products", "list", "-filter", "eq(sku,FRG)"},
+			stdout: `"name": "Filtered Frog"`,
+		},
+		{
+			args:   []string{"products", "create", "-f", productFile},
+			stdout: `"id": "crane"`,
+		},
+		{
+			args:   []string{"products", "create"},
+			stdin:  `{"data":{"name":"Origami Swan","sku":"SWN"}}`,
+			stdout: `"name": "Origami Swan"`,
+		},
+		{
+			args:   []string{"currencies", "delete", "gbp"},
+			stdout: "",
+		},
+		{
+			args:   []string{"--verbose", "products", "list"},
+			stdout: `"name": "Origami Frog"`,
+			stderr: `epcc: level=info msg="authentication successful" method=POST path=/oauth/access_token`,
+		},
+		{
+			args:   []string{"currencies", "get", "missing"},
+			code:   1,
+			stderr: "epcc: status code 404 is not ok\n{\n  \"errors\": [\n    {\n      \"status\": 404,\n      \"title\": \"Currency not found\",\n      \"detail\": \"The requested currency could not be found\"\n    }\n  ]\n}\n",
+		},
+		{
+			args:   []string{"currencies", "get"},
+			code:   2,
+			stderr: "usage: epcc",
+		},
+		{
+			args:   []string{"orders", "list"},
+			code:   2,
+			stderr: "usage: epcc",
+		},
+		{
+			args:   []string{"--profile", "missing", "products", "list"},
+			code:   1,
+			stderr: "epcc: error profile missing not found in " + configPath + "\n",
+		},
+		{
+			args:   []string{"--profile", "other", "--base-url", testServer.URL, "products", "list"},
+			code:   1,
+			stderr: "epcc: error: unexpected status 401 Unauthorized\n",
+		},
+	}
+
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		args := append([]string{"--config", configPath}, test.args...)
+
+		code := run(args, strings.NewReader(test.stdin), &stdout, &stderr)
+		assert.Equal(t, test.code, code, test.args)
+		assert.Contains(t, stdout.String(), test.stdout, test.args)
+		if test.code == 1 {
+			assert.Equal(t, test.stderr, stderr.String(), test.args)
+		} else {
+			assert.Contains(t, stderr.String(), test.stderr, test.args)
+		}
+	}
+}
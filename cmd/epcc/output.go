@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+)
+
+// Output formats.
+const (
+	outputTable    = "table"
+	outputJSON     = "json"
+	outputNDJSON   = "ndjson"
+	outputCSV      = "csv"
+	outputTemplate = "template"
+)
+
+// Default columns of the table and CSV output formats.
+const (
+	productColumns  = "id,sku,name,status,commodity_type,price.amount,price.currency,meta.stock.level,meta.stock.availability"
+	currencyColumns = "id,code,exchange_rate,format,decimal_places,default,enabled"
+)
+
+// output holds how results are written.
+type output struct {
+	format   string
+	columns  string
+	template string
+}
+
+// validate checks the output format is known and has what it needs.
+func (o output) validate() error {
+	switch o.format {
+	case outputTable, outputJSON, outputNDJSON, outputCSV:
+		return nil
+	case outputTemplate:
+		if o.template == "" {
+			return fmt.Errorf("error --template is required with --output template")
+		}
+		_, err := template.New("output").Parse(o.template)
+		return err
+	}
+	return fmt.Errorf("error unknown output format %q, use table, json, ndjson, csv or template", o.format)
+}
+
+// printItem writes a single resource in the output format.
+func (c *cli) printItem(item interface{}, defaultColumns string) error {
+	if c.output.format == outputJSON {
+		c.remember([]interface{}{item})
+		return c.print(item)
+	}
+	return c.printList([]interface{}{item}, defaultColumns)
+}
+
+// printList writes a slice of resources in the output format.
+func (c *cli) printList(items interface{}, defaultColumns string) error {
+	values := reflect.ValueOf(items)
+	list := make([]interface{}, values.Len())
+	for i := range list {
+		list[i] = values.Index(i).Interface()
+	}
+
+	c.remember(list)
+
+	columns := c.output.columns
+	if columns == "" {
+		columns = defaultColumns
+	}
+
+	switch c.output.format {
+	case outputTable:
+		return c.printTable(list, strings.Split(columns, ","))
+	case outputNDJSON:
+		encoder := json.NewEncoder(c.stdout)
+		for _, item := range list {
+			if err := encoder.Encode(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case outputCSV:
+		return c.printCSV(list, strings.Split(columns, ","))
+	case outputTemplate:
+		return c.printTemplate(list)
+	}
+	return c.print(items)
+}
+
+func (c *cli) printTable(list []interface{}, columns []string) error {
+	rows, err := cells(list, columns)
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = strings.ToUpper(column)
+	}
+	fmt.Fprintln(writer, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+	return writer.Flush()
+}
+
+func (c *cli) printCSV(list []interface{}, columns []string) error {
+	rows, err := cells(list, columns)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(c.stdout)
+	writer.Write(columns)
+	writer.WriteAll(rows)
+	return writer.Error()
+}
+
+// printTemplate executes the template once for each resource, each on its own line.
+func (c *cli) printTemplate(list []interface{}) error {
+	tmpl, err := template.New("output").Parse(c.output.template)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range list {
+		var buffer bytes.Buffer
+		if err := tmpl.Execute(&buffer, item); err != nil {
+			return err
+		}
+		if !bytes.HasSuffix(buffer.Bytes(), []byte("\n")) {
+			buffer.WriteByte('\n')
+		}
+		if _, err := c.stdout.Write(buffer.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cells returns the value of each column for each resource.
+func cells(list []interface{}, columns []string) ([][]string, error) {
+	rows := make([][]string, len(list))
+	for i, item := range list {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+
+		// Numbers are kept as they are sent, so large amounts are not written as floats.
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		var fields interface{}
+		if err := decoder.Decode(&fields); err != nil {
+			return nil, err
+		}
+
+		row := make([]string, len(columns))
+		for j, column := range columns {
+			row[j] = formatCell(lookup(fields, strings.Split(strings.TrimSpace(column), ".")))
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+// lookup follows a dotted path through decoded JSON, such as meta.stock.level or price.0.amount.
+// A path through a list without an index returns the value from each element.
+func lookup(value interface{}, path []string) interface{} {
+	if len(path) == 0 {
+		return value
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return lookup(v[path[0]], path[1:])
+	case []interface{}:
+		if index, err := strconv.Atoi(path[0]); err == nil {
+			if index < 0 || index >= len(v) {
+				return nil
+			}
+			return lookup(v[index], path[1:])
+		}
+		values := make([]interface{}, len(v))
+		for i := range v {
+			values[i] = lookup(v[i], path)
+		}
+		return values
+	}
+	return nil
+}
+
+// formatCell writes a value as text, values from each element of a list are separated by semicolons.
+func formatCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		values := make([]string, len(v))
+		for i := range v {
+			values[i] = formatCell(v[i])
+		}
+		return strings.Join(values, ";")
+	}
+
+	data, _ := json.Marshal(value)
+	return string(data)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintList(t *testing.T) {
+	products := []epcc.Product{
+		{
+			Type:   "product",
+			ID:     "frog",
+			SKU:    "FRG",
+			Name:   "Origami Frog",
+			Status: epcc.ProductStatusLive,
+			Price: []epcc.ProductPrice{
+				{Amount: 1000000, Currency: "GBP"},
+				{Amount: 1200000, Currency: "EUR"},
+			},
+			Meta: epcc.ProductMeta{Stock: epcc.ProductStock{Level: 7, Availability: epcc.StockInStock}},
+		},
+		{
+			Type: "product",
+			ID:   "cat",
+			SKU:  "CAT",
+			Name: "Origami Cat, Large",
+		},
+	}
+
+	tests := []struct {
+		output   output
+		expected string
+	}{
+		{
+			output{format: outputTable, columns: "id,name,price.amount,meta.stock.level"},
+			"ID    NAME                PRICE.AMOUNT     META.STOCK.LEVEL\n" +
+				"frog  Origami Frog        1000000;1200000  7\n" +
+				"cat   Origami Cat, Large                   0\n",
+		},
+		{
+			output{format: outputCSV, columns: "sku,name,price.0.currency,meta.stock.availability,missing"},
+			"sku,name,price.0.currency,meta.stock.availability,missing\n" +
+				"FRG,Origami Frog,GBP,in-stock,\n" +
+				"CAT,\"Origami Cat, Large\",,,\n",
+		},
+		{
+			output{format: outputCSV},
+			"id,sku,name,status,commodity_type,price.amount,price.currency,meta.stock.level,meta.stock.availability\n" +
+				"frog,FRG,Origami Frog,live,,1000000;1200000,GBP;EUR,7,in-stock\n" +
+				"cat,CAT,\"Origami Cat, Large\",,,,,0,\n",
+		},
+		{
+			output{format: outputTemplate, template: "{{.SKU}}: {{len .Price}} prices"},
+			"FRG: 2 prices\nCAT: 0 prices\n",
+		},
+	}
+
+	for _, test := range tests {
+		var stdout bytes.Buffer
+		c := &cli{output: test.output, stdout: &stdout}
+
+		err := c.printList(products, productColumns)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, test.expected, stdout.String(), test.output.format)
+	}
+
+	// Each product is written as compact JSON on its own line.
+	var stdout bytes.Buffer
+	c := &cli{output: output{format: outputNDJSON}, stdout: &stdout}
+	assert.Equal(t, nil, c.printList(products, productColumns))
+
+	lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+	assert.Equal(t, 2, len(lines))
+	for i, line := range lines {
+		var product epcc.Product
+		assert.Equal(t, nil, json.Unmarshal([]byte(line), &product))
+		assert.Equal(t, products[i].ID, product.ID)
+		assert.Equal(t, products[i].Price, product.Price)
+	}
+}
+
+func TestPrintItem(t *testing.T) {
+	currency := epcc.Currency{Type: "currency", ID: "gbp", Code: "GBP", ExchangeRate: 1, Default: true}
+
+	var stdout bytes.Buffer
+	c := &cli{output: output{format: outputJSON}, stdout: &stdout}
+	assert.Equal(t, nil, c.printItem(currency, currencyColumns))
+	assert.Contains(t, stdout.String(), "{\n  \"id\": \"gbp\",\n  \"type\": \"currency\",\n  \"code\": \"GBP\",\n")
+
+	stdout.Reset()
+	c.output = output{format: outputTable}
+	assert.Equal(t, nil, c.printItem(currency, currencyColumns))
+	assert.Equal(t, "ID   CODE  EXCHANGE_RATE  FORMAT  DECIMAL_PLACES  DEFAULT  ENABLED\ngbp  GBP   1                                      true     \n", stdout.String())
+}
+
+func TestOutputValidate(t *testing.T) {
+	tests := []struct {
+		output output
+		err    error
+	}{
+		{output{format: outputJSON}, nil},
+		{output{format: outputTemplate, template: "{{.ID}}"}, nil},
+		{output{format: outputTemplate}, errors.New("error --template is required with --output template")},
+		{output{format: "xml"}, errors.New(`error unknown output format "xml", use table, json, ndjson, csv or template`)},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.err, test.output.validate())
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/Rosalita/go-epcc-client"
+)
+
+func productsList(c *cli, args []string) error {
+	flags := flag.NewFlagSet("products list", flag.ContinueOnError)
+	filter := flags.String("filter", "", "a filter such as eq(status,live)")
+	if err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+
+	var products *epcc.ProductsData
+	var err error
+	if *filter != "" {
+		products, err = epcc.Products.GetAllFiltered(c.client, epcc.RawFilter(*filter))
+	} else {
+		products, err = epcc.Products.GetAll(c.client)
+	}
+	if err != nil {
+		return err
+	}
+	return c.printList(products.Data, productColumns)
+}
+
+func productsGet(c *cli, args []string) error {
+	flags := flag.NewFlagSet("products get", flag.ContinueOnError)
+	if err := parseArgs(flags, args, 1); err != nil {
+		return err
+	}
+
+	product, err := epcc.Products.Get(c.client, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	return c.printItem(product.Data, productColumns)
+}
+
+func productsCreate(c *cli, args []string) error {
+	flags := flag.NewFlagSet("products create", flag.ContinueOnError)
+	file := flags.String("f", "", "the JSON or YAML file to read, - is stdin")
+	if err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+
+	var product epcc.Product
+	if err := c.readInput(*file, &product); err != nil {
+		return err
+	}
+	if product.Type == "" {
+		product.Type = "product"
+	}
+
+	created, err := epcc.Products.Create(c.client, &product)
+	if err != nil {
+		return err
+	}
+	return c.printItem(created.Data, productColumns)
+}
+
+func productsUpdate(c *cli, args []string) error {
+	flags := flag.NewFlagSet("products update", flag.ContinueOnError)
+	file := flags.String("f", "", "the JSON or YAML file to read, - is stdin")
+	if err := parseArgs(flags, args, 1); err != nil {
+		return err
+	}
+
+	var product epcc.Product
+	if err := c.readInput(*file, &product); err != nil {
+		return err
+	}
+	product.ID = flags.Arg(0)
+	if product.Type == "" {
+		product.Type = "product"
+	}
+
+	updated, err := epcc.Products.Update(c.client, &product)
+	if err != nil {
+		return err
+	}
+	return c.printItem(updated.Data, productColumns)
+}
+
+func productsDelete(c *cli, args []string) error {
+	flags := flag.NewFlagSet("products delete", flag.ContinueOnError)
+	if err := parseArgs(flags, args, 1); err != nil {
+		return err
+	}
+
+	return epcc.Products.Delete(c.client, flags.Arg(0))
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// profile holds the credentials and base URL of a store.
+type profile struct {
+	BaseURL      string `yaml:"base_url"`
+	ClientID     string `yaml:"client_id"`
+	ClientSecret string `yaml:"client_secret"`
+}
+
+// config is the layout of the config file, for example:
+//
+//	default: staging
+//	profiles:
+//	  staging:
+//	    base_url: https://api.moltin.com/
+//	    client_id: XXX
+//	    client_secret: YYY
+type config struct {
+	Default  string             `yaml:"default"`
+	Profiles map[string]profile `yaml:"profiles"`
+}
+
+// defaultConfigPath returns the path of the config file, EPCC_CONFIG overrides it.
+func defaultConfigPath() string {
+	if path := os.Getenv("EPCC_CONFIG"); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "epcc", "config.yaml")
+}
+
+// loadProfile returns the named profile, or the default profile when name is empty.
+// When no profile is named and the config file does not exist, an empty profile is
+// returned so the client uses the base URL and credentials from the environment.
+func loadProfile(path string, name string) (profile, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) && name == "" {
+		return profile{}, nil
+	}
+	if err != nil {
+		return profile{}, err
+	}
+
+	var cfg config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return profile{}, fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	if name == "" {
+		name = cfg.Default
+	}
+	if name == "" {
+		return profile{}, nil
+	}
+
+	p, ok := cfg.Profiles[name]
+	if !ok {
+		return profile{}, fmt.Errorf("error profile %s not found in %s", name, path)
+	}
+	return p, nil
+}
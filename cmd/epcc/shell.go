@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const shellPrompt = "epcc> "
+
+const shellHelp = `Run any command without the epcc prefix, such as:
+  products list
+  products get <id>
+  products update <id> -f update.yaml
+  currencies list
+Tab completes commands and the IDs of resources already listed or fetched.
+Other commands:
+  history  list the commands run
+  help     show this help
+  exit     leave the shell
+`
+
+// lineReader reads a line of input after showing a prompt.
+type lineReader interface {
+	ReadLine(prompt string) (string, error)
+}
+
+// shell runs commands read from the terminal until exit, keeping the client authenticated.
+func (c *cli) shell() int {
+	// Input must be given with -f in the shell, as stdin is the terminal.
+	stdin := c.stdin
+	c.stdin = strings.NewReader("")
+	c.ids = map[string][]string{}
+
+	var reader lineReader
+	var history func() []string
+	if file, ok := stdin.(*os.File); ok {
+		if restore, err := makeRaw(int(file.Fd())); err == nil {
+			defer restore()
+			editor := newLineEditor(file, c.stdout, c.completions)
+			reader = editor
+			history = func() []string { return editor.history }
+		}
+	}
+	if reader == nil {
+		plain := &plainLineReader{in: bufio.NewScanner(stdin), out: c.stdout}
+		reader = plain
+		history = func() []string { return plain.history }
+	}
+
+	for {
+		line, err := reader.ReadLine(shellPrompt)
+		if err == errInterrupt {
+			continue
+		}
+		if err == io.EOF {
+			return 0
+		}
+		if err != nil {
+			fmt.Fprintf(c.stderr, "epcc: %s\n", err)
+			return 1
+		}
+
+		words, err := splitWords(line)
+		if err != nil {
+			fmt.Fprintf(c.stderr, "epcc: %s\n", err)
+			continue
+		}
+		if len(words) == 0 {
+			continue
+		}
+
+		switch words[0] {
+		case "exit", "quit":
+			return 0
+		case "help":
+			fmt.Fprint(c.stdout, shellHelp)
+			continue
+		case "history":
+			for i, entry := range history() {
+				fmt.Fprintf(c.stdout, "%4d  %s\n", i+1, entry)
+			}
+			continue
+		}
+
+		if len(words) < 2 || commands[words[0]] == nil || commands[words[0]][words[1]] == nil {
+			fmt.Fprintf(c.stderr, "epcc: unknown command %q, type help for help\n", strings.Join(words, " "))
+			continue
+		}
+
+		c.resource = words[0]
+		if err := commands[words[0]][words[1]](c, words[2:]); err != nil {
+			if err == errUsage {
+				fmt.Fprintf(c.stderr, "epcc: usage: %s %s, type help for help\n", words[0], words[1])
+				continue
+			}
+			c.fail(err)
+		}
+	}
+}
+
+// remember records the IDs of resources printed in the shell so they can be completed.
+func (c *cli) remember(list []interface{}) {
+	if c.ids == nil {
+		return
+	}
+
+	for _, item := range list {
+		value := reflect.Indirect(reflect.ValueOf(item))
+		if value.Kind() != reflect.Struct {
+			continue
+		}
+		field := value.FieldByName("ID")
+		if !field.IsValid() || field.Kind() != reflect.String || field.String() == "" {
+			continue
+		}
+
+		id := field.String()
+		known := false
+		for _, seen := range c.ids[c.resource] {
+			if seen == id {
+				known = true
+				break
+			}
+		}
+		if !known {
+			c.ids[c.resource] = append(c.ids[c.resource], id)
+		}
+	}
+}
+
+// completions returns the words which complete the last word of line:
+// commands, then subcommands, then the IDs seen for the resource.
+func (c *cli) completions(line string) []string {
+	words := strings.Split(line, " ")
+	word := words[len(words)-1]
+
+	var options []string
+	switch len(words) {
+	case 1:
+		options = []string{"exit", "help", "history"}
+		for name := range commands {
+			options = append(options, name)
+		}
+	case 2:
+		for name := range commands[words[0]] {
+			options = append(options, name)
+		}
+	case 3:
+		switch words[1] {
+		case "get", "update", "delete":
+			options = c.ids[words[0]]
+		}
+	}
+
+	var matches []string
+	for _, option := range options {
+		if strings.HasPrefix(option, word) {
+			matches = append(matches, option)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// splitWords splits a line into words, quotes group words containing spaces.
+func splitWords(line string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+
+	for _, r := range line {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			word.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, errors.New("error unterminated quote")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
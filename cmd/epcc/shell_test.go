@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineEditor(t *testing.T) {
+	complete := func(line string) []string {
+		options := []string{"products", "promotions", "currencies"}
+		var matches []string
+		for _, option := range options {
+			if strings.HasPrefix(option, line) {
+				matches = append(matches, option)
+			}
+		}
+		return matches
+	}
+
+	input := "cu\tlist\r" + // a single completion is completed with a space
+		"pr\x7fr\t\t\t\x15" + // the common prefix is completed, tabbing again lists the completions, ctrl-u clears the line
+		"x\x1b[Dy\r" + // left arrow moves the cursor before x
+		"\x1b[A\x1b[A\r" + // up arrow recalls history
+		"abc\x03" + // ctrl-c abandons the line
+		"\x04" // ctrl-d on an empty line ends input
+
+	var out bytes.Buffer
+	editor := newLineEditor(strings.NewReader(input), &out, complete)
+
+	tests := []struct {
+		line string
+		err  error
+	}{
+		{"currencies list", nil},
+		{"yx", nil},
+		{"currencies list", nil},
+		{"", errInterrupt},
+		{"", io.EOF},
+	}
+
+	for _, test := range tests {
+		line, err := editor.ReadLine("> ")
+		assert.Equal(t, test.line, line)
+		assert.Equal(t, test.err, err)
+	}
+
+	assert.Equal(t, []string{"currencies list", "yx", "currencies list"}, editor.history)
+	assert.Contains(t, out.String(), "\r\nproducts  promotions\r\n")
+}
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		line  string
+		words []string
+		err   error
+	}{
+		{"products list", []string{"products", "list"}, nil},
+		{"  products   get  frog ", []string{"products", "get", "frog"}, nil},
+		{`products list -filter 'eq(name,"Origami Frog")'`, []string{"products", "list", "-filter", `eq(name,"Origami Frog")`}, nil},
+		{`products get ""`, []string{"products", "get", ""}, nil},
+		{`products list -filter "eq(sku,FRG)`, nil, errors.New("error unterminated quote")},
+		{"", nil, nil},
+	}
+
+	for _, test := range tests {
+		words, err := splitWords(test.line)
+		assert.Equal(t, test.words, words)
+		assert.Equal(t, test.err, err)
+	}
+}
+
+func TestShell(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(fakeHandleCLI))
+	defer testServer.Close()
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	config := "profiles:\n  test:\n    base_url: " + testServer.URL + "\n    client_id: profileClientID\n    client_secret: profileClientSecret\n"
+	assert.Equal(t, nil, ioutil.WriteFile(configPath, []byte(config), 0600))
+
+	input := strings.Join([]string{
+		"help",
+		"products list",
+		"currencies get missing",
+		"products get",
+		"orders list",
+		"history",
+		"exit",
+		"products list",
+	}, "\n")
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"--config", configPath, "--profile", "test", "shell"}
+	code := run(args, strings.NewReader(input), &stdout, &stderr)
+	assert.Equal(t, 0, code)
+
+	// Commands after exit are not run.
+	assert.Equal(t, 1, strings.Count(stdout.String(), `"name": "Origami Frog"`))
+	assert.Contains(t, stdout.String(), "Tab completes commands")
+	assert.Contains(t, stdout.String(), "   1  help\n   2  products list\n   3  currencies get missing\n")
+	assert.Contains(t, stderr.String(), "epcc: status code 404 is not ok\n")
+	assert.Contains(t, stderr.String(), "epcc: usage: products get, type help for help\n")
+	assert.Contains(t, stderr.String(), "epcc: unknown command \"orders list\", type help for help\n")
+}
+
+func TestShellAuthenticatesAgain(t *testing.T) {
+	// Each token is revoked once it has been used to list products.
+	var issued int
+	var valid string
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/oauth/access_token" {
+			issued++
+			valid = fmt.Sprintf("token-%d", issued)
+			rw.WriteHeader(200)
+			rw.Write([]byte(`{"access_token":"` + valid + `","token_type":"Bearer","expires_in":3600}`))
+			return
+		}
+		if req.Header.Get("Authorization") != "Bearer "+valid {
+			rw.WriteHeader(401)
+			return
+		}
+		valid = ""
+		rw.WriteHeader(200)
+		rw.Write([]byte(`{"data":[{"type":"product","id":"frog","name":"Origami Frog","sku":"FRG"}]}`))
+	}))
+	defer testServer.Close()
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	config := "profiles:\n  test:\n    base_url: " + testServer.URL + "\n    client_id: profileClientID\n    client_secret: profileClientSecret\n"
+	assert.Equal(t, nil, ioutil.WriteFile(configPath, []byte(config), 0600))
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"--config", configPath, "--profile", "test", "shell"}
+	code := run(args, strings.NewReader("products list\nproducts list\nexit\n"), &stdout, &stderr)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, 2, strings.Count(stdout.String(), `"name": "Origami Frog"`))
+	assert.Equal(t, "", stderr.String())
+	assert.Equal(t, 2, issued)
+}
+
+func TestShellCompletions(t *testing.T) {
+	c := &cli{ids: map[string][]string{}, resource: "products"}
+	c.remember([]interface{}{struct{ ID string }{"frog"}, struct{ ID string }{"fox"}, struct{ ID string }{"frog"}})
+
+	tests := []struct {
+		line     string
+		expected []string
+	}{
+		{"", []string{"auth", "currencies", "exit", "help", "history", "products"}},
+		{"pro", []string{"products"}},
+		{"products ", []string{"create", "delete", "get", "list", "update"}},
+		{"products g", []string{"get"}},
+		{"products get f", []string{"fox", "frog"}},
+		{"products get fr", []string{"frog"}},
+		{"products list f", nil},
+		{"currencies get f", nil},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, c.completions(test.line), test.line)
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+// +build !linux
+
+package main
+
+import "errors"
+
+// makeRaw is only supported on linux, other platforms read whole lines without completion.
+func makeRaw(fd int) (func(), error) {
+	return nil, errors.New("error raw mode is not supported on this platform")
+}
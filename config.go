@@ -3,6 +3,7 @@ package epcc
 import (
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
@@ -19,24 +20,16 @@ func init() {
 	cfg.RetryLimitTimeout = 30 * time.Second
 
 	// If the package is being tested, ignore environment variables.
-	if len(os.Args) > 1 && os.Args[1][:5] == "-test" {
-		log.Println("Package initialised in testing mode.")
-		log.Println("Environment variables will be ignored.")
+	if len(os.Args) > 1 && strings.HasPrefix(os.Args[1], "-test") {
 		return
 	}
 
 	// Otherwise, process environment variables and store them in the global cfg.
+	// Missing credentials are reported by Authenticate, as clients may be given their own.
 	err := envconfig.Process("", &cfg)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
-
-	if cfg.Credentials.ClientID == "" {
-		log.Fatal("Required environment variable GO_EPCC_CLIENT_ID not found")
-	}
-	if cfg.Credentials.ClientSecret == "" {
-		log.Fatal("Required environment variable GO_EPCC_CLIENT_SECRET not found")
-	}
 }
 
 // Config is used to keep track of configuration in one place.
@@ -28,17 +28,20 @@ func (currencies) Get(client *Client, currencyID string) (*CurrencyData, error)
 	return &currencies, nil
 }
 
-// GetAll fetches all currencies
+// GetAll fetches all currencies, requesting every page of the results.
 func (currencies) GetAll(client *Client) (*CurrenciesData, error) {
 	path := fmt.Sprintf("/v2/currencies")
 
-	body, err := client.DoRequest("GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-
 	var currencies CurrenciesData
-	if err := json.Unmarshal(body, &currencies); err != nil {
+	err := getPages(client, path, func(body []byte) (int, error) {
+		var page CurrenciesData
+		if err := json.Unmarshal(body, &page); err != nil {
+			return 0, err
+		}
+		currencies.Data = append(currencies.Data, page.Data...)
+		return len(page.Data), nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -47,6 +50,10 @@ func (currencies) GetAll(client *Client) (*CurrenciesData, error) {
 
 // Create creates a currency
 func (currencies) Create(client *Client, currency *Currency) (*CurrencyData, error) {
+	if err := validatePayload(client, currency.Validate); err != nil {
+		return nil, err
+	}
+
 	currencyData := CurrencyData{
 		Data: *currency,
 	}
@@ -84,6 +91,9 @@ func (currencies) Delete(client *Client, currencyID string) error {
 
 // Update updates a currency.
 func (currencies) Update(client *Client, currencyID string, currency *Currency) (*CurrencyData, error) {
+	if err := validatePayload(client, currency.ValidateUpdate); err != nil {
+		return nil, err
+	}
 
 	currencyData := CurrencyData{
 		Data: *currency,
@@ -2,7 +2,6 @@ package epcc_test
 
 import (
 	"bytes"
-	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -329,7 +328,12 @@ func TestCurrenciesCreate(t *testing.T) {
 		err          error
 	}{
 		{validNewCurrency, &expectedCurrencyData, nil},
-		{currencyAlreadyExists, nil, errors.New("status code 400 is not ok")},
+		{currencyAlreadyExists, nil, &epcc.APIError{
+			StatusCode: 400,
+			Errors: []epcc.ErrorDetail{
+				{Status: 400, Title: "Currency already exists", Detail: "The specified currency code already exists for this store"},
+			},
+		}},
 	}
 
 	// Create a new client and configure it to use test server instead of the real API endpoint.
@@ -387,8 +391,18 @@ func TestCurrenciesDelete(t *testing.T) {
 		err        error
 	}{
 		{"validCurrencyID", nil},
-		{"notFound", errors.New("status code 404 is not ok")},
-		{"defaultCurrency", errors.New("status code 400 is not ok")},
+		{"notFound", &epcc.APIError{
+			StatusCode: 404,
+			Errors: []epcc.ErrorDetail{
+				{Status: 404, Title: "Currency not found", Detail: "The requested currency could not be found"},
+			},
+		}},
+		{"defaultCurrency", &epcc.APIError{
+			StatusCode: 400,
+			Errors: []epcc.ErrorDetail{
+				{Status: 400, Title: "Cannot delete default currency", Detail: "Make another currency default before removing"},
+			},
+		}},
 	}
 
 	// Create a new client and configure it to use test server instead of the real API endpoint.
@@ -0,0 +1,120 @@
+package epcc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pricePlaceholder is replaced by the formatted amount in a currency's Format.
+const pricePlaceholder = "{price}"
+
+// FormatAmount formats an amount in the minor unit of the currency, such as pence or cents,
+// using the currency's Format, DecimalPoint, ThousandSeparator and DecimalPlaces.
+// Negative amounts are prefixed with a minus sign, so -1050 in GBP is "-£10.50".
+func (c Currency) FormatAmount(amount int) string {
+	// The magnitude is unsigned so the smallest int, which has no positive int, can be negated.
+	sign := ""
+	magnitude := uint64(amount)
+	if amount < 0 {
+		sign = "-"
+		magnitude = -magnitude
+	}
+
+	digits := strconv.FormatUint(magnitude, 10)
+
+	places := int(c.DecimalPlaces)
+	if places < 0 {
+		places = 0
+	}
+
+	// Pad so there is always at least one digit before the decimal point.
+	if len(digits) <= places {
+		digits = strings.Repeat("0", places-len(digits)+1) + digits
+	}
+
+	whole := digits[:len(digits)-places]
+	fraction := digits[len(digits)-places:]
+
+	var price strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			price.WriteString(c.ThousandSeparator)
+		}
+		price.WriteRune(digit)
+	}
+	if places > 0 {
+		price.WriteString(c.DecimalPoint)
+		price.WriteString(fraction)
+	}
+
+	return sign + strings.Replace(c.format(), pricePlaceholder, price.String(), 1)
+}
+
+// FormatPrice formats a product price, which must be in this currency.
+func (c Currency) FormatPrice(price ProductPrice) (string, error) {
+	if !strings.EqualFold(price.Currency, c.Code) {
+		return "", fmt.Errorf("error price currency %s does not match currency %s", price.Currency, c.Code)
+	}
+
+	return c.FormatAmount(price.Amount), nil
+}
+
+// ParseAmount parses text formatted by FormatAmount back into an amount in the minor unit of the currency.
+// Thousand separators are optional and fewer decimal places than the currency has are accepted.
+func (c Currency) ParseAmount(text string) (int, error) {
+	format := c.format()
+	placeholder := strings.Index(format, pricePlaceholder)
+	prefix := format[:placeholder]
+	suffix := format[placeholder+len(pricePlaceholder):]
+
+	price := strings.TrimSpace(text)
+	negative := strings.HasPrefix(price, "-")
+	price = strings.TrimPrefix(price, "-")
+
+	if !strings.HasPrefix(price, prefix) || !strings.HasSuffix(price, suffix) || len(price) < len(prefix)+len(suffix) {
+		return 0, fmt.Errorf("error %q does not match format %q", text, format)
+	}
+	price = price[len(prefix) : len(price)-len(suffix)]
+
+	whole, fraction := price, ""
+	if c.DecimalPlaces > 0 && c.DecimalPoint != "" {
+		if i := strings.LastIndex(price, c.DecimalPoint); i >= 0 {
+			whole, fraction = price[:i], price[i+len(c.DecimalPoint):]
+		}
+	}
+	if c.ThousandSeparator != "" {
+		whole = strings.Replace(whole, c.ThousandSeparator, "", -1)
+	}
+
+	places := int(c.DecimalPlaces)
+	if places < 0 {
+		places = 0
+	}
+	if len(fraction) > places {
+		return 0, fmt.Errorf("error %q has more than %d decimal places", text, places)
+	}
+
+	digits := whole + fraction + strings.Repeat("0", places-len(fraction))
+	if whole == "" || strings.Trim(digits, "0123456789") != "" {
+		return 0, fmt.Errorf("error %q is not a valid amount", text)
+	}
+
+	amount, err := strconv.ParseInt(digits, 10, 0)
+	if err != nil {
+		return 0, fmt.Errorf("error %q is not a valid amount", text)
+	}
+
+	if negative {
+		amount = -amount
+	}
+	return int(amount), nil
+}
+
+// format returns the currency's Format, falling back to the bare price when it has no placeholder.
+func (c Currency) format() string {
+	if !strings.Contains(c.Format, pricePlaceholder) {
+		return pricePlaceholder
+	}
+	return c.Format
+}
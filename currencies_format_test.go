@@ -0,0 +1,96 @@
+package epcc_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	gbp = epcc.Currency{Code: "GBP", Format: "£{price}", DecimalPoint: ".", ThousandSeparator: ",", DecimalPlaces: 2}
+	eur = epcc.Currency{Code: "EUR", Format: "{price} €", DecimalPoint: ",", ThousandSeparator: ".", DecimalPlaces: 2}
+	jpy = epcc.Currency{Code: "JPY", Format: "¥{price}", DecimalPoint: ".", ThousandSeparator: ",", DecimalPlaces: 0}
+	bhd = epcc.Currency{Code: "BHD", Format: "BD {price}", DecimalPoint: ".", ThousandSeparator: " ", DecimalPlaces: 3}
+	inr = epcc.Currency{Code: "INR", Format: "₹{price}", DecimalPoint: ".", ThousandSeparator: "", DecimalPlaces: 2}
+)
+
+func TestCurrencyFormatAmount(t *testing.T) {
+	tests := []struct {
+		currency  epcc.Currency
+		amount    int
+		formatted string
+	}{
+		{gbp, 0, "£0.00"},
+		{gbp, 5, "£0.05"},
+		{gbp, 1050, "£10.50"},
+		{gbp, -1050, "-£10.50"},
+		{gbp, 123456789, "£1,234,567.89"},
+		{gbp, 100000, "£1,000.00"},
+		{eur, 123456789, "1.234.567,89 €"},
+		{eur, -99, "-0,99 €"},
+		{jpy, 1500, "¥1,500"},
+		{jpy, 999, "¥999"},
+		{jpy, -1234567, "-¥1,234,567"},
+		{bhd, 1234567, "BD 1 234.567"},
+		{inr, 1234567, "₹12345.67"},
+		{epcc.Currency{DecimalPoint: ".", DecimalPlaces: 2}, 150, "1.50"},
+		{gbp, math.MinInt64, "-£92,233,720,368,547,758.08"},
+		{gbp, math.MaxInt64, "£92,233,720,368,547,758.07"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.formatted, test.currency.FormatAmount(test.amount))
+	}
+}
+
+func TestCurrencyFormatPrice(t *testing.T) {
+	formatted, err := gbp.FormatPrice(epcc.ProductPrice{Amount: 1999, Currency: "GBP"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "£19.99", formatted)
+
+	_, err = gbp.FormatPrice(epcc.ProductPrice{Amount: 1999, Currency: "USD"})
+	assert.Equal(t, errors.New("error price currency USD does not match currency GBP"), err)
+}
+
+func TestCurrencyParseAmount(t *testing.T) {
+	tests := []struct {
+		currency epcc.Currency
+		text     string
+		amount   int
+		err      error
+	}{
+		{gbp, "£10.50", 1050, nil},
+		{gbp, "-£10.50", -1050, nil},
+		{gbp, "£1,234,567.89", 123456789, nil},
+		{gbp, "£1234567.89", 123456789, nil},
+		{gbp, "£10.5", 1050, nil},
+		{gbp, "£10", 1000, nil},
+		{gbp, " £0.05 ", 5, nil},
+		{gbp, "$10.50", 0, errors.New(`error "$10.50" does not match format "£{price}"`)},
+		{gbp, "£10.505", 0, errors.New(`error "£10.505" has more than 2 decimal places`)},
+		{gbp, "£ten", 0, errors.New(`error "£ten" is not a valid amount`)},
+		{gbp, "£.50", 0, errors.New(`error "£.50" is not a valid amount`)},
+		{eur, "1.234.567,89 €", 123456789, nil},
+		{jpy, "¥1,500", 1500, nil},
+		{jpy, "¥1,500.5", 0, errors.New(`error "¥1,500.5" is not a valid amount`)},
+		{bhd, "BD 1 234.567", 1234567, nil},
+	}
+
+	for _, test := range tests {
+		amount, err := test.currency.ParseAmount(test.text)
+		assert.Equal(t, test.amount, amount, test.text)
+		assert.Equal(t, test.err, err, test.text)
+	}
+
+	// Formatting and parsing are the reverse of each other.
+	for _, amount := range []int{0, 1, -1, 99, 100, 123456789, -987654321} {
+		for _, currency := range []epcc.Currency{gbp, eur, jpy, bhd, inr} {
+			parsed, err := currency.ParseAmount(currency.FormatAmount(amount))
+			assert.Equal(t, nil, err)
+			assert.Equal(t, amount, parsed)
+		}
+	}
+}
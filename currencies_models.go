@@ -1,5 +1,7 @@
 package epcc
 
+import "encoding/json"
+
 // CurrencyData contains the data for a single currency
 type CurrencyData struct {
 	Data Currency `json:"data"`
@@ -24,6 +26,74 @@ type Currency struct {
 	Enabled           bool         `json:"enabled,omitempty"`
 	Links             Links        `json:"links,omitempty"`
 	Meta              CurrencyMeta `json:"meta,omitempty"`
+	// attributes is a pointer so Currency stays comparable, the map it points to is never changed once it is set.
+	attributes *map[string]json.RawMessage
+}
+
+// UnmarshalJSON decodes a currency, keeping any attributes which are not fields of Currency.
+func (c *Currency) UnmarshalJSON(data []byte) error {
+	type currency Currency
+	var decoded currency
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	attributes, err := unknownAttributes(data, decoded)
+	if err != nil {
+		return err
+	}
+
+	*c = Currency(decoded)
+	c.setAttributes(attributes)
+	return nil
+}
+
+// MarshalJSON encodes a currency, including any attributes which are not fields of Currency.
+func (c Currency) MarshalJSON() ([]byte, error) {
+	type currency Currency
+	data, err := json.Marshal(currency(c))
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeAttributes(data, c.attributeMap())
+}
+
+// Attributes returns a copy of the attributes of the currency which are not fields of Currency.
+func (c Currency) Attributes() map[string]json.RawMessage {
+	return copyAttributes(c.attributeMap())
+}
+
+// Attribute decodes an attribute which is not a field of Currency into v and reports whether it exists.
+func (c Currency) Attribute(name string, v interface{}) (bool, error) {
+	return getAttribute(c.attributeMap(), name, v)
+}
+
+// SetAttribute sets an attribute which is not a field of Currency, it is sent when the currency is encoded.
+func (c *Currency) SetAttribute(name string, v interface{}) error {
+	attributes, err := setAttribute(copyAttributes(c.attributeMap()), *c, name, v)
+	if err != nil {
+		return err
+	}
+
+	c.setAttributes(attributes)
+	return nil
+}
+
+// attributeMap returns the attributes of the currency which are not fields of Currency.
+func (c Currency) attributeMap() map[string]json.RawMessage {
+	if c.attributes == nil {
+		return nil
+	}
+	return *c.attributes
+}
+
+// setAttributes replaces the attributes of the currency which are not fields of Currency.
+func (c *Currency) setAttributes(attributes map[string]json.RawMessage) {
+	c.attributes = nil
+	if attributes != nil {
+		c.attributes = &attributes
+	}
 }
 
 // CurrencyMeta contains extra data for a currency
@@ -34,4 +104,4 @@ type CurrencyMeta struct {
 // Links contains link information
 type Links struct {
 	Self string `json:"self"`
-}
\ No newline at end of file
+}
@@ -160,3 +160,35 @@ func TestMultipleCurrenciesDataUnmarshal(t *testing.T) {
 	assert.Equal(t, nil, err)
 	assert.Equal(t, expectedCurrenciesData, currenciesData)
 }
+
+func TestCurrencyUnknownAttributesRoundTrip(t *testing.T) {
+	rawJSON := `{"data":{"id":"f8f0689e-4767-4924-b112-be89f490e1f5","type":"currency","code":"INR","rounding":"half_up"}}`
+
+	var currencyData epcc.CurrencyData
+	err := json.Unmarshal([]byte(rawJSON), &currencyData)
+	assert.Equal(t, nil, err)
+
+	var rounding string
+	found, err := currencyData.Data.Attribute("rounding", &rounding)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, found)
+	assert.Equal(t, "half_up", rounding)
+
+	encoded, err := json.Marshal(currencyData)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{"data":{"code":"INR","id":"f8f0689e-4767-4924-b112-be89f490e1f5","links":{"self":""},"meta":{"timestamps":{}},"rounding":"half_up","type":"currency"}}`, string(encoded))
+
+	// SetAttribute adds an unknown attribute to a currency, and copies made before it are not changed.
+	currency := epcc.Currency{Type: "currency", Code: "GBP"}
+	copied := currency
+	err = currency.SetAttribute("rounding", "half_even")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]json.RawMessage{"rounding": json.RawMessage(`"half_even"`)}, currency.Attributes())
+	assert.Equal(t, true, copied == epcc.Currency{Type: "currency", Code: "GBP"})
+	assert.Equal(t, false, copied == currency)
+
+	// Currencies without unknown attributes encode exactly as before.
+	encoded, err = json.Marshal(epcc.Currency{Type: "currency", Code: "GBP"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{"type":"currency","code":"GBP","links":{"self":""},"meta":{"timestamps":{}}}`, string(encoded))
+}
@@ -0,0 +1,109 @@
+package epcc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Entries is used to access the Entries endpoints.
+var Entries entries
+
+type entries struct{}
+
+// Get fetches a single entry of a flow
+func (entries) Get(client *Client, flowSlug string, entryID string) (*EntryData, error) {
+	path := fmt.Sprintf("/v2/flows/%s/entries/%s", flowSlug, entryID)
+
+	body, err := client.DoRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry EntryData
+	if err := json.Unmarshal(body, &entry); err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// GetAll fetches all entries of a flow
+func (entries) GetAll(client *Client, flowSlug string) (*EntriesData, error) {
+	path := fmt.Sprintf("/v2/flows/%s/entries", flowSlug)
+
+	body, err := client.DoRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries EntriesData
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return nil, err
+	}
+
+	return &entries, nil
+}
+
+// Create creates an entry in a flow
+func (entries) Create(client *Client, flowSlug string, entry *Entry) (*EntryData, error) {
+	entryData := EntryData{
+		Data: *entry,
+	}
+
+	jsonPayload, err := json.Marshal(entryData)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/v2/flows/%s/entries", flowSlug)
+
+	body, err := client.DoRequest("POST", path, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, err
+	}
+
+	var newEntry EntryData
+	if err := json.Unmarshal(body, &newEntry); err != nil {
+		return nil, err
+	}
+
+	return &newEntry, nil
+}
+
+// Update updates an entry in a flow.
+func (entries) Update(client *Client, flowSlug string, entryID string, entry *Entry) (*EntryData, error) {
+	entryData := EntryData{
+		Data: *entry,
+	}
+
+	jsonPayload, err := json.Marshal(entryData)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/v2/flows/%s/entries/%s", flowSlug, entryID)
+
+	body, err := client.DoRequest("PUT", path, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedEntry EntryData
+	if err := json.Unmarshal(body, &updatedEntry); err != nil {
+		return nil, err
+	}
+
+	return &updatedEntry, nil
+}
+
+// Delete deletes an entry from a flow.
+func (entries) Delete(client *Client, flowSlug string, entryID string) error {
+	path := fmt.Sprintf("/v2/flows/%s/entries/%s", flowSlug, entryID)
+
+	if _, err := client.DoRequest("DELETE", path, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,726 @@
+// Code generated by internal/mockgen from services.go. DO NOT EDIT.
+
+package epccmock
+
+import (
+	"io"
+	"time"
+
+	"github.com/Rosalita/go-epcc-client"
+)
+
+// RequestExecutor is a mock of epcc.RequestExecutor.
+type RequestExecutor struct {
+	recorder
+
+	DoRequestFunc func(method string, path string, payload io.Reader) ([]byte, error)
+}
+
+// DoRequest records the call and calls DoRequestFunc.
+func (m *RequestExecutor) DoRequest(method string, path string, payload io.Reader) ([]byte, error) {
+	m.record("DoRequest", method, path, payload)
+	if m.DoRequestFunc == nil {
+		panic("epccmock: RequestExecutor.DoRequest called but DoRequestFunc is not set")
+	}
+	return m.DoRequestFunc(method, path, payload)
+}
+
+// AccountMembersService is a mock of epcc.AccountMembersService.
+type AccountMembersService struct {
+	recorder
+
+	GetFunc    func(accountMemberID string) (*epcc.AccountMemberData, error)
+	GetAllFunc func() (*epcc.AccountMembersData, error)
+}
+
+// Get records the call and calls GetFunc.
+func (m *AccountMembersService) Get(accountMemberID string) (*epcc.AccountMemberData, error) {
+	m.record("Get", accountMemberID)
+	if m.GetFunc == nil {
+		panic("epccmock: AccountMembersService.Get called but GetFunc is not set")
+	}
+	return m.GetFunc(accountMemberID)
+}
+
+// GetAll records the call and calls GetAllFunc.
+func (m *AccountMembersService) GetAll() (*epcc.AccountMembersData, error) {
+	m.record("GetAll")
+	if m.GetAllFunc == nil {
+		panic("epccmock: AccountMembersService.GetAll called but GetAllFunc is not set")
+	}
+	return m.GetAllFunc()
+}
+
+// AccountMembershipsService is a mock of epcc.AccountMembershipsService.
+type AccountMembershipsService struct {
+	recorder
+
+	GetFunc    func(accountID string, membershipID string) (*epcc.AccountMembershipData, error)
+	GetAllFunc func(accountID string) (*epcc.AccountMembershipsData, error)
+	CreateFunc func(accountID string, accountMemberID string) (*epcc.AccountMembershipData, error)
+	DeleteFunc func(accountID string, membershipID string) error
+}
+
+// Get records the call and calls GetFunc.
+func (m *AccountMembershipsService) Get(accountID string, membershipID string) (*epcc.AccountMembershipData, error) {
+	m.record("Get", accountID, membershipID)
+	if m.GetFunc == nil {
+		panic("epccmock: AccountMembershipsService.Get called but GetFunc is not set")
+	}
+	return m.GetFunc(accountID, membershipID)
+}
+
+// GetAll records the call and calls GetAllFunc.
+func (m *AccountMembershipsService) GetAll(accountID string) (*epcc.AccountMembershipsData, error) {
+	m.record("GetAll", accountID)
+	if m.GetAllFunc == nil {
+		panic("epccmock: AccountMembershipsService.GetAll called but GetAllFunc is not set")
+	}
+	return m.GetAllFunc(accountID)
+}
+
+// Create records the call and calls CreateFunc.
+func (m *AccountMembershipsService) Create(accountID string, accountMemberID string) (*epcc.AccountMembershipData, error) {
+	m.record("Create", accountID, accountMemberID)
+	if m.CreateFunc == nil {
+		panic("epccmock: AccountMembershipsService.Create called but CreateFunc is not set")
+	}
+	return m.CreateFunc(accountID, accountMemberID)
+}
+
+// Delete records the call and calls DeleteFunc.
+func (m *AccountMembershipsService) Delete(accountID string, membershipID string) error {
+	m.record("Delete", accountID, membershipID)
+	if m.DeleteFunc == nil {
+		panic("epccmock: AccountMembershipsService.Delete called but DeleteFunc is not set")
+	}
+	return m.DeleteFunc(accountID, membershipID)
+}
+
+// AccountsService is a mock of epcc.AccountsService.
+type AccountsService struct {
+	recorder
+
+	GetFunc    func(accountID string) (*epcc.AccountData, error)
+	GetAllFunc func() (*epcc.AccountsData, error)
+	CreateFunc func(account *epcc.Account) (*epcc.AccountData, error)
+	UpdateFunc func(accountID string, account *epcc.Account) (*epcc.AccountData, error)
+	DeleteFunc func(accountID string) error
+}
+
+// Get records the call and calls GetFunc.
+func (m *AccountsService) Get(accountID string) (*epcc.AccountData, error) {
+	m.record("Get", accountID)
+	if m.GetFunc == nil {
+		panic("epccmock: AccountsService.Get called but GetFunc is not set")
+	}
+	return m.GetFunc(accountID)
+}
+
+// GetAll records the call and calls GetAllFunc.
+func (m *AccountsService) GetAll() (*epcc.AccountsData, error) {
+	m.record("GetAll")
+	if m.GetAllFunc == nil {
+		panic("epccmock: AccountsService.GetAll called but GetAllFunc is not set")
+	}
+	return m.GetAllFunc()
+}
+
+// Create records the call and calls CreateFunc.
+func (m *AccountsService) Create(account *epcc.Account) (*epcc.AccountData, error) {
+	m.record("Create", account)
+	if m.CreateFunc == nil {
+		panic("epccmock: AccountsService.Create called but CreateFunc is not set")
+	}
+	return m.CreateFunc(account)
+}
+
+// Update records the call and calls UpdateFunc.
+func (m *AccountsService) Update(accountID string, account *epcc.Account) (*epcc.AccountData, error) {
+	m.record("Update", accountID, account)
+	if m.UpdateFunc == nil {
+		panic("epccmock: AccountsService.Update called but UpdateFunc is not set")
+	}
+	return m.UpdateFunc(accountID, account)
+}
+
+// Delete records the call and calls DeleteFunc.
+func (m *AccountsService) Delete(accountID string) error {
+	m.record("Delete", accountID)
+	if m.DeleteFunc == nil {
+		panic("epccmock: AccountsService.Delete called but DeleteFunc is not set")
+	}
+	return m.DeleteFunc(accountID)
+}
+
+// CurrenciesService is a mock of epcc.CurrenciesService.
+type CurrenciesService struct {
+	recorder
+
+	GetFunc    func(currencyID string) (*epcc.CurrencyData, error)
+	GetAllFunc func() (*epcc.CurrenciesData, error)
+	CreateFunc func(currency *epcc.Currency) (*epcc.CurrencyData, error)
+	UpdateFunc func(currencyID string, currency *epcc.Currency) (*epcc.CurrencyData, error)
+	DeleteFunc func(currencyID string) error
+}
+
+// Get records the call and calls GetFunc.
+func (m *CurrenciesService) Get(currencyID string) (*epcc.CurrencyData, error) {
+	m.record("Get", currencyID)
+	if m.GetFunc == nil {
+		panic("epccmock: CurrenciesService.Get called but GetFunc is not set")
+	}
+	return m.GetFunc(currencyID)
+}
+
+// GetAll records the call and calls GetAllFunc.
+func (m *CurrenciesService) GetAll() (*epcc.CurrenciesData, error) {
+	m.record("GetAll")
+	if m.GetAllFunc == nil {
+		panic("epccmock: CurrenciesService.GetAll called but GetAllFunc is not set")
+	}
+	return m.GetAllFunc()
+}
+
+// Create records the call and calls CreateFunc.
+func (m *CurrenciesService) Create(currency *epcc.Currency) (*epcc.CurrencyData, error) {
+	m.record("Create", currency)
+	if m.CreateFunc == nil {
+		panic("epccmock: CurrenciesService.Create called but CreateFunc is not set")
+	}
+	return m.CreateFunc(currency)
+}
+
+// Update records the call and calls UpdateFunc.
+func (m *CurrenciesService) Update(currencyID string, currency *epcc.Currency) (*epcc.CurrencyData, error) {
+	m.record("Update", currencyID, currency)
+	if m.UpdateFunc == nil {
+		panic("epccmock: CurrenciesService.Update called but UpdateFunc is not set")
+	}
+	return m.UpdateFunc(currencyID, currency)
+}
+
+// Delete records the call and calls DeleteFunc.
+func (m *CurrenciesService) Delete(currencyID string) error {
+	m.record("Delete", currencyID)
+	if m.DeleteFunc == nil {
+		panic("epccmock: CurrenciesService.Delete called but DeleteFunc is not set")
+	}
+	return m.DeleteFunc(currencyID)
+}
+
+// EntriesService is a mock of epcc.EntriesService.
+type EntriesService struct {
+	recorder
+
+	GetFunc    func(flowSlug string, entryID string) (*epcc.EntryData, error)
+	GetAllFunc func(flowSlug string) (*epcc.EntriesData, error)
+	CreateFunc func(flowSlug string, entry *epcc.Entry) (*epcc.EntryData, error)
+	UpdateFunc func(flowSlug string, entryID string, entry *epcc.Entry) (*epcc.EntryData, error)
+	DeleteFunc func(flowSlug string, entryID string) error
+}
+
+// Get records the call and calls GetFunc.
+func (m *EntriesService) Get(flowSlug string, entryID string) (*epcc.EntryData, error) {
+	m.record("Get", flowSlug, entryID)
+	if m.GetFunc == nil {
+		panic("epccmock: EntriesService.Get called but GetFunc is not set")
+	}
+	return m.GetFunc(flowSlug, entryID)
+}
+
+// GetAll records the call and calls GetAllFunc.
+func (m *EntriesService) GetAll(flowSlug string) (*epcc.EntriesData, error) {
+	m.record("GetAll", flowSlug)
+	if m.GetAllFunc == nil {
+		panic("epccmock: EntriesService.GetAll called but GetAllFunc is not set")
+	}
+	return m.GetAllFunc(flowSlug)
+}
+
+// Create records the call and calls CreateFunc.
+func (m *EntriesService) Create(flowSlug string, entry *epcc.Entry) (*epcc.EntryData, error) {
+	m.record("Create", flowSlug, entry)
+	if m.CreateFunc == nil {
+		panic("epccmock: EntriesService.Create called but CreateFunc is not set")
+	}
+	return m.CreateFunc(flowSlug, entry)
+}
+
+// Update records the call and calls UpdateFunc.
+func (m *EntriesService) Update(flowSlug string, entryID string, entry *epcc.Entry) (*epcc.EntryData, error) {
+	m.record("Update", flowSlug, entryID, entry)
+	if m.UpdateFunc == nil {
+		panic("epccmock: EntriesService.Update called but UpdateFunc is not set")
+	}
+	return m.UpdateFunc(flowSlug, entryID, entry)
+}
+
+// Delete records the call and calls DeleteFunc.
+func (m *EntriesService) Delete(flowSlug string, entryID string) error {
+	m.record("Delete", flowSlug, entryID)
+	if m.DeleteFunc == nil {
+		panic("epccmock: EntriesService.Delete called but DeleteFunc is not set")
+	}
+	return m.DeleteFunc(flowSlug, entryID)
+}
+
+// FieldsService is a mock of epcc.FieldsService.
+type FieldsService struct {
+	recorder
+
+	GetFunc    func(fieldID string) (*epcc.FieldData, error)
+	GetAllFunc func(flowSlug string) (*epcc.FieldsData, error)
+	CreateFunc func(field *epcc.Field) (*epcc.FieldData, error)
+	UpdateFunc func(fieldID string, field *epcc.Field) (*epcc.FieldData, error)
+	DeleteFunc func(fieldID string) error
+}
+
+// Get records the call and calls GetFunc.
+func (m *FieldsService) Get(fieldID string) (*epcc.FieldData, error) {
+	m.record("Get", fieldID)
+	if m.GetFunc == nil {
+		panic("epccmock: FieldsService.Get called but GetFunc is not set")
+	}
+	return m.GetFunc(fieldID)
+}
+
+// GetAll records the call and calls GetAllFunc.
+func (m *FieldsService) GetAll(flowSlug string) (*epcc.FieldsData, error) {
+	m.record("GetAll", flowSlug)
+	if m.GetAllFunc == nil {
+		panic("epccmock: FieldsService.GetAll called but GetAllFunc is not set")
+	}
+	return m.GetAllFunc(flowSlug)
+}
+
+// Create records the call and calls CreateFunc.
+func (m *FieldsService) Create(field *epcc.Field) (*epcc.FieldData, error) {
+	m.record("Create", field)
+	if m.CreateFunc == nil {
+		panic("epccmock: FieldsService.Create called but CreateFunc is not set")
+	}
+	return m.CreateFunc(field)
+}
+
+// Update records the call and calls UpdateFunc.
+func (m *FieldsService) Update(fieldID string, field *epcc.Field) (*epcc.FieldData, error) {
+	m.record("Update", fieldID, field)
+	if m.UpdateFunc == nil {
+		panic("epccmock: FieldsService.Update called but UpdateFunc is not set")
+	}
+	return m.UpdateFunc(fieldID, field)
+}
+
+// Delete records the call and calls DeleteFunc.
+func (m *FieldsService) Delete(fieldID string) error {
+	m.record("Delete", fieldID)
+	if m.DeleteFunc == nil {
+		panic("epccmock: FieldsService.Delete called but DeleteFunc is not set")
+	}
+	return m.DeleteFunc(fieldID)
+}
+
+// FlowsService is a mock of epcc.FlowsService.
+type FlowsService struct {
+	recorder
+
+	GetFunc    func(flowID string) (*epcc.FlowData, error)
+	GetAllFunc func() (*epcc.FlowsData, error)
+	CreateFunc func(flow *epcc.Flow) (*epcc.FlowData, error)
+	UpdateFunc func(flowID string, flow *epcc.Flow) (*epcc.FlowData, error)
+	DeleteFunc func(flowID string) error
+}
+
+// Get records the call and calls GetFunc.
+func (m *FlowsService) Get(flowID string) (*epcc.FlowData, error) {
+	m.record("Get", flowID)
+	if m.GetFunc == nil {
+		panic("epccmock: FlowsService.Get called but GetFunc is not set")
+	}
+	return m.GetFunc(flowID)
+}
+
+// GetAll records the call and calls GetAllFunc.
+func (m *FlowsService) GetAll() (*epcc.FlowsData, error) {
+	m.record("GetAll")
+	if m.GetAllFunc == nil {
+		panic("epccmock: FlowsService.GetAll called but GetAllFunc is not set")
+	}
+	return m.GetAllFunc()
+}
+
+// Create records the call and calls CreateFunc.
+func (m *FlowsService) Create(flow *epcc.Flow) (*epcc.FlowData, error) {
+	m.record("Create", flow)
+	if m.CreateFunc == nil {
+		panic("epccmock: FlowsService.Create called but CreateFunc is not set")
+	}
+	return m.CreateFunc(flow)
+}
+
+// Update records the call and calls UpdateFunc.
+func (m *FlowsService) Update(flowID string, flow *epcc.Flow) (*epcc.FlowData, error) {
+	m.record("Update", flowID, flow)
+	if m.UpdateFunc == nil {
+		panic("epccmock: FlowsService.Update called but UpdateFunc is not set")
+	}
+	return m.UpdateFunc(flowID, flow)
+}
+
+// Delete records the call and calls DeleteFunc.
+func (m *FlowsService) Delete(flowID string) error {
+	m.record("Delete", flowID)
+	if m.DeleteFunc == nil {
+		panic("epccmock: FlowsService.Delete called but DeleteFunc is not set")
+	}
+	return m.DeleteFunc(flowID)
+}
+
+// IntegrationsService is a mock of epcc.IntegrationsService.
+type IntegrationsService struct {
+	recorder
+
+	GetFunc        func(integrationID string) (*epcc.IntegrationData, error)
+	GetAllFunc     func() (*epcc.IntegrationsData, error)
+	CreateFunc     func(integration *epcc.Integration) (*epcc.IntegrationData, error)
+	UpdateFunc     func(integrationID string, integration *epcc.Integration) (*epcc.IntegrationData, error)
+	DeleteFunc     func(integrationID string) error
+	GetAllLogsFunc func() (*epcc.IntegrationLogsData, error)
+	GetLogsFunc    func(integrationID string) (*epcc.IntegrationLogsData, error)
+	GetJobsFunc    func(integrationID string) (*epcc.IntegrationJobsData, error)
+	GetJobLogsFunc func(integrationID string, jobID string) (*epcc.IntegrationLogsData, error)
+}
+
+// Get records the call and calls GetFunc.
+func (m *IntegrationsService) Get(integrationID string) (*epcc.IntegrationData, error) {
+	m.record("Get", integrationID)
+	if m.GetFunc == nil {
+		panic("epccmock: IntegrationsService.Get called but GetFunc is not set")
+	}
+	return m.GetFunc(integrationID)
+}
+
+// GetAll records the call and calls GetAllFunc.
+func (m *IntegrationsService) GetAll() (*epcc.IntegrationsData, error) {
+	m.record("GetAll")
+	if m.GetAllFunc == nil {
+		panic("epccmock: IntegrationsService.GetAll called but GetAllFunc is not set")
+	}
+	return m.GetAllFunc()
+}
+
+// Create records the call and calls CreateFunc.
+func (m *IntegrationsService) Create(integration *epcc.Integration) (*epcc.IntegrationData, error) {
+	m.record("Create", integration)
+	if m.CreateFunc == nil {
+		panic("epccmock: IntegrationsService.Create called but CreateFunc is not set")
+	}
+	return m.CreateFunc(integration)
+}
+
+// Update records the call and calls UpdateFunc.
+func (m *IntegrationsService) Update(integrationID string, integration *epcc.Integration) (*epcc.IntegrationData, error) {
+	m.record("Update", integrationID, integration)
+	if m.UpdateFunc == nil {
+		panic("epccmock: IntegrationsService.Update called but UpdateFunc is not set")
+	}
+	return m.UpdateFunc(integrationID, integration)
+}
+
+// Delete records the call and calls DeleteFunc.
+func (m *IntegrationsService) Delete(integrationID string) error {
+	m.record("Delete", integrationID)
+	if m.DeleteFunc == nil {
+		panic("epccmock: IntegrationsService.Delete called but DeleteFunc is not set")
+	}
+	return m.DeleteFunc(integrationID)
+}
+
+// GetAllLogs records the call and calls GetAllLogsFunc.
+func (m *IntegrationsService) GetAllLogs() (*epcc.IntegrationLogsData, error) {
+	m.record("GetAllLogs")
+	if m.GetAllLogsFunc == nil {
+		panic("epccmock: IntegrationsService.GetAllLogs called but GetAllLogsFunc is not set")
+	}
+	return m.GetAllLogsFunc()
+}
+
+// GetLogs records the call and calls GetLogsFunc.
+func (m *IntegrationsService) GetLogs(integrationID string) (*epcc.IntegrationLogsData, error) {
+	m.record("GetLogs", integrationID)
+	if m.GetLogsFunc == nil {
+		panic("epccmock: IntegrationsService.GetLogs called but GetLogsFunc is not set")
+	}
+	return m.GetLogsFunc(integrationID)
+}
+
+// GetJobs records the call and calls GetJobsFunc.
+func (m *IntegrationsService) GetJobs(integrationID string) (*epcc.IntegrationJobsData, error) {
+	m.record("GetJobs", integrationID)
+	if m.GetJobsFunc == nil {
+		panic("epccmock: IntegrationsService.GetJobs called but GetJobsFunc is not set")
+	}
+	return m.GetJobsFunc(integrationID)
+}
+
+// GetJobLogs records the call and calls GetJobLogsFunc.
+func (m *IntegrationsService) GetJobLogs(integrationID string, jobID string) (*epcc.IntegrationLogsData, error) {
+	m.record("GetJobLogs", integrationID, jobID)
+	if m.GetJobLogsFunc == nil {
+		panic("epccmock: IntegrationsService.GetJobLogs called but GetJobLogsFunc is not set")
+	}
+	return m.GetJobLogsFunc(integrationID, jobID)
+}
+
+// ProductsService is a mock of epcc.ProductsService.
+type ProductsService struct {
+	recorder
+
+	GetFunc                 func(productID string) (*epcc.ProductData, error)
+	GetAllFunc              func() (*epcc.ProductsData, error)
+	GetAllFilteredFunc      func(filter epcc.Filter) (*epcc.ProductsData, error)
+	GetUpdatedSinceFunc     func(since time.Time) (*epcc.ProductsData, error)
+	GetBySKUFunc            func(sku string) (*epcc.ProductData, error)
+	GetBySlugFunc           func(slug string) (*epcc.ProductData, error)
+	CreateFunc              func(product *epcc.Product) (*epcc.ProductData, error)
+	UpdateFunc              func(product *epcc.Product) (*epcc.ProductData, error)
+	DeleteFunc              func(productID string) error
+	GetWithExtensionFunc    func(productID string, ext interface{}) (*epcc.ProductData, error)
+	CreateWithExtensionFunc func(product *epcc.Product, ext interface{}) (*epcc.ProductData, error)
+	UpdateWithExtensionFunc func(product *epcc.Product, ext interface{}) (*epcc.ProductData, error)
+	CreateManyFunc          func(products []epcc.Product, options epcc.BulkOptions) ([]epcc.BulkResult, error)
+	UpdateManyFunc          func(products []epcc.Product, options epcc.BulkOptions) ([]epcc.BulkResult, error)
+	UpsertBySKUFunc         func(products []epcc.Product, options epcc.BulkOptions) ([]epcc.BulkResult, error)
+}
+
+// Get records the call and calls GetFunc.
+func (m *ProductsService) Get(productID string) (*epcc.ProductData, error) {
+	m.record("Get", productID)
+	if m.GetFunc == nil {
+		panic("epccmock: ProductsService.Get called but GetFunc is not set")
+	}
+	return m.GetFunc(productID)
+}
+
+// GetAll records the call and calls GetAllFunc.
+func (m *ProductsService) GetAll() (*epcc.ProductsData, error) {
+	m.record("GetAll")
+	if m.GetAllFunc == nil {
+		panic("epccmock: ProductsService.GetAll called but GetAllFunc is not set")
+	}
+	return m.GetAllFunc()
+}
+
+// GetAllFiltered records the call and calls GetAllFilteredFunc.
+func (m *ProductsService) GetAllFiltered(filter epcc.Filter) (*epcc.ProductsData, error) {
+	m.record("GetAllFiltered", filter)
+	if m.GetAllFilteredFunc == nil {
+		panic("epccmock: ProductsService.GetAllFiltered called but GetAllFilteredFunc is not set")
+	}
+	return m.GetAllFilteredFunc(filter)
+}
+
+// GetUpdatedSince records the call and calls GetUpdatedSinceFunc.
+func (m *ProductsService) GetUpdatedSince(since time.Time) (*epcc.ProductsData, error) {
+	m.record("GetUpdatedSince", since)
+	if m.GetUpdatedSinceFunc == nil {
+		panic("epccmock: ProductsService.GetUpdatedSince called but GetUpdatedSinceFunc is not set")
+	}
+	return m.GetUpdatedSinceFunc(since)
+}
+
+// GetBySKU records the call and calls GetBySKUFunc.
+func (m *ProductsService) GetBySKU(sku string) (*epcc.ProductData, error) {
+	m.record("GetBySKU", sku)
+	if m.GetBySKUFunc == nil {
+		panic("epccmock: ProductsService.GetBySKU called but GetBySKUFunc is not set")
+	}
+	return m.GetBySKUFunc(sku)
+}
+
+// GetBySlug records the call and calls GetBySlugFunc.
+func (m *ProductsService) GetBySlug(slug string) (*epcc.ProductData, error) {
+	m.record("GetBySlug", slug)
+	if m.GetBySlugFunc == nil {
+		panic("epccmock: ProductsService.GetBySlug called but GetBySlugFunc is not set")
+	}
+	return m.GetBySlugFunc(slug)
+}
+
+// Create records the call and calls CreateFunc.
+func (m *ProductsService) Create(product *epcc.Product) (*epcc.ProductData, error) {
+	m.record("Create", product)
+	if m.CreateFunc == nil {
+		panic("epccmock: ProductsService.Create called but CreateFunc is not set")
+	}
+	return m.CreateFunc(product)
+}
+
+// Update records the call and calls UpdateFunc.
+func (m *ProductsService) Update(product *epcc.Product) (*epcc.ProductData, error) {
+	m.record("Update", product)
+	if m.UpdateFunc == nil {
+		panic("epccmock: ProductsService.Update called but UpdateFunc is not set")
+	}
+	return m.UpdateFunc(product)
+}
+
+// Delete records the call and calls DeleteFunc.
+func (m *ProductsService) Delete(productID string) error {
+	m.record("Delete", productID)
+	if m.DeleteFunc == nil {
+		panic("epccmock: ProductsService.Delete called but DeleteFunc is not set")
+	}
+	return m.DeleteFunc(productID)
+}
+
+// GetWithExtension records the call and calls GetWithExtensionFunc.
+func (m *ProductsService) GetWithExtension(productID string, ext interface{}) (*epcc.ProductData, error) {
+	m.record("GetWithExtension", productID, ext)
+	if m.GetWithExtensionFunc == nil {
+		panic("epccmock: ProductsService.GetWithExtension called but GetWithExtensionFunc is not set")
+	}
+	return m.GetWithExtensionFunc(productID, ext)
+}
+
+// CreateWithExtension records the call and calls CreateWithExtensionFunc.
+func (m *ProductsService) CreateWithExtension(product *epcc.Product, ext interface{}) (*epcc.ProductData, error) {
+	m.record("CreateWithExtension", product, ext)
+	if m.CreateWithExtensionFunc == nil {
+		panic("epccmock: ProductsService.CreateWithExtension called but CreateWithExtensionFunc is not set")
+	}
+	return m.CreateWithExtensionFunc(product, ext)
+}
+
+// UpdateWithExtension records the call and calls UpdateWithExtensionFunc.
+func (m *ProductsService) UpdateWithExtension(product *epcc.Product, ext interface{}) (*epcc.ProductData, error) {
+	m.record("UpdateWithExtension", product, ext)
+	if m.UpdateWithExtensionFunc == nil {
+		panic("epccmock: ProductsService.UpdateWithExtension called but UpdateWithExtensionFunc is not set")
+	}
+	return m.UpdateWithExtensionFunc(product, ext)
+}
+
+// CreateMany records the call and calls CreateManyFunc.
+func (m *ProductsService) CreateMany(products []epcc.Product, options epcc.BulkOptions) ([]epcc.BulkResult, error) {
+	m.record("CreateMany", products, options)
+	if m.CreateManyFunc == nil {
+		panic("epccmock: ProductsService.CreateMany called but CreateManyFunc is not set")
+	}
+	return m.CreateManyFunc(products, options)
+}
+
+// UpdateMany records the call and calls UpdateManyFunc.
+func (m *ProductsService) UpdateMany(products []epcc.Product, options epcc.BulkOptions) ([]epcc.BulkResult, error) {
+	m.record("UpdateMany", products, options)
+	if m.UpdateManyFunc == nil {
+		panic("epccmock: ProductsService.UpdateMany called but UpdateManyFunc is not set")
+	}
+	return m.UpdateManyFunc(products, options)
+}
+
+// UpsertBySKU records the call and calls UpsertBySKUFunc.
+func (m *ProductsService) UpsertBySKU(products []epcc.Product, options epcc.BulkOptions) ([]epcc.BulkResult, error) {
+	m.record("UpsertBySKU", products, options)
+	if m.UpsertBySKUFunc == nil {
+		panic("epccmock: ProductsService.UpsertBySKU called but UpsertBySKUFunc is not set")
+	}
+	return m.UpsertBySKUFunc(products, options)
+}
+
+// PromotionsService is a mock of epcc.PromotionsService.
+type PromotionsService struct {
+	recorder
+
+	GetFunc           func(promotionID string) (*epcc.PromotionData, error)
+	GetAllFunc        func() (*epcc.PromotionsData, error)
+	CreateFunc        func(promotion *epcc.Promotion) (*epcc.PromotionData, error)
+	UpdateFunc        func(promotionID string, promotion *epcc.Promotion) (*epcc.PromotionData, error)
+	DeleteFunc        func(promotionID string) error
+	GetCodesFunc      func(promotionID string) (*epcc.PromotionCodesData, error)
+	CreateCodesFunc   func(promotionID string, codes []epcc.PromotionCode) error
+	DeleteCodesFunc   func(promotionID string, codes []epcc.PromotionCode) error
+	GenerateCodesFunc func(promotionID string, generator epcc.PromotionCodeGenerator) (*epcc.PromotionCodeResult, error)
+}
+
+// Get records the call and calls GetFunc.
+func (m *PromotionsService) Get(promotionID string) (*epcc.PromotionData, error) {
+	m.record("Get", promotionID)
+	if m.GetFunc == nil {
+		panic("epccmock: PromotionsService.Get called but GetFunc is not set")
+	}
+	return m.GetFunc(promotionID)
+}
+
+// GetAll records the call and calls GetAllFunc.
+func (m *PromotionsService) GetAll() (*epcc.PromotionsData, error) {
+	m.record("GetAll")
+	if m.GetAllFunc == nil {
+		panic("epccmock: PromotionsService.GetAll called but GetAllFunc is not set")
+	}
+	return m.GetAllFunc()
+}
+
+// Create records the call and calls CreateFunc.
+func (m *PromotionsService) Create(promotion *epcc.Promotion) (*epcc.PromotionData, error) {
+	m.record("Create", promotion)
+	if m.CreateFunc == nil {
+		panic("epccmock: PromotionsService.Create called but CreateFunc is not set")
+	}
+	return m.CreateFunc(promotion)
+}
+
+// Update records the call and calls UpdateFunc.
+func (m *PromotionsService) Update(promotionID string, promotion *epcc.Promotion) (*epcc.PromotionData, error) {
+	m.record("Update", promotionID, promotion)
+	if m.UpdateFunc == nil {
+		panic("epccmock: PromotionsService.Update called but UpdateFunc is not set")
+	}
+	return m.UpdateFunc(promotionID, promotion)
+}
+
+// Delete records the call and calls DeleteFunc.
+func (m *PromotionsService) Delete(promotionID string) error {
+	m.record("Delete", promotionID)
+	if m.DeleteFunc == nil {
+		panic("epccmock: PromotionsService.Delete called but DeleteFunc is not set")
+	}
+	return m.DeleteFunc(promotionID)
+}
+
+// GetCodes records the call and calls GetCodesFunc.
+func (m *PromotionsService) GetCodes(promotionID string) (*epcc.PromotionCodesData, error) {
+	m.record("GetCodes", promotionID)
+	if m.GetCodesFunc == nil {
+		panic("epccmock: PromotionsService.GetCodes called but GetCodesFunc is not set")
+	}
+	return m.GetCodesFunc(promotionID)
+}
+
+// CreateCodes records the call and calls CreateCodesFunc.
+func (m *PromotionsService) CreateCodes(promotionID string, codes []epcc.PromotionCode) error {
+	m.record("CreateCodes", promotionID, codes)
+	if m.CreateCodesFunc == nil {
+		panic("epccmock: PromotionsService.CreateCodes called but CreateCodesFunc is not set")
+	}
+	return m.CreateCodesFunc(promotionID, codes)
+}
+
+// DeleteCodes records the call and calls DeleteCodesFunc.
+func (m *PromotionsService) DeleteCodes(promotionID string, codes []epcc.PromotionCode) error {
+	m.record("DeleteCodes", promotionID, codes)
+	if m.DeleteCodesFunc == nil {
+		panic("epccmock: PromotionsService.DeleteCodes called but DeleteCodesFunc is not set")
+	}
+	return m.DeleteCodesFunc(promotionID, codes)
+}
+
+// GenerateCodes records the call and calls GenerateCodesFunc.
+func (m *PromotionsService) GenerateCodes(promotionID string, generator epcc.PromotionCodeGenerator) (*epcc.PromotionCodeResult, error) {
+	m.record("GenerateCodes", promotionID, generator)
+	if m.GenerateCodesFunc == nil {
+		panic("epccmock: PromotionsService.GenerateCodes called but GenerateCodesFunc is not set")
+	}
+	return m.GenerateCodesFunc(promotionID, generator)
+}
@@ -0,0 +1,80 @@
+package epccmock_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/Rosalita/go-epcc-client/epccmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ epcc.RequestExecutor           = &epccmock.RequestExecutor{}
+	_ epcc.AccountMembersService     = &epccmock.AccountMembersService{}
+	_ epcc.AccountMembershipsService = &epccmock.AccountMembershipsService{}
+	_ epcc.AccountsService           = &epccmock.AccountsService{}
+	_ epcc.CurrenciesService         = &epccmock.CurrenciesService{}
+	_ epcc.EntriesService            = &epccmock.EntriesService{}
+	_ epcc.FieldsService             = &epccmock.FieldsService{}
+	_ epcc.FlowsService              = &epccmock.FlowsService{}
+	_ epcc.IntegrationsService       = &epccmock.IntegrationsService{}
+	_ epcc.ProductsService           = &epccmock.ProductsService{}
+	_ epcc.PromotionsService         = &epccmock.PromotionsService{}
+)
+
+// renameBySKU is domain code which depends on the products service.
+func renameBySKU(products epcc.ProductsService, sku string, name string) error {
+	product, err := products.GetBySKU(sku)
+	if err != nil {
+		return err
+	}
+	product.Data.Name = name
+	_, err = products.Update(&product.Data)
+	return err
+}
+
+func TestProductsServiceMock(t *testing.T) {
+	var updated *epcc.Product
+	products := &epccmock.ProductsService{
+		GetBySKUFunc: func(sku string) (*epcc.ProductData, error) {
+			return &epcc.ProductData{Data: epcc.Product{ID: "productID", SKU: sku, Name: "Crane"}}, nil
+		},
+		UpdateFunc: func(product *epcc.Product) (*epcc.ProductData, error) {
+			updated = product
+			return &epcc.ProductData{Data: *product}, nil
+		},
+	}
+
+	assert.Equal(t, nil, renameBySKU(products, "CRN", "Paper Crane"))
+	assert.Equal(t, "Paper Crane", updated.Name)
+	assert.Equal(t, []epccmock.Call{
+		{Method: "GetBySKU", Args: []interface{}{"CRN"}},
+		{Method: "Update", Args: []interface{}{updated}},
+	}, products.Calls())
+	assert.Equal(t, 1, products.CallCount("Update"))
+	assert.Equal(t, 0, products.CallCount("Delete"))
+}
+
+func TestMockErrors(t *testing.T) {
+	products := &epccmock.ProductsService{
+		GetBySKUFunc: func(sku string) (*epcc.ProductData, error) {
+			return nil, &epcc.NotFoundError{Resource: "product", Key: "sku", Value: sku}
+		},
+	}
+	err := renameBySKU(products, "CRN", "Paper Crane")
+	assert.Equal(t, true, epcc.IsNotFound(err))
+
+	executor := &epccmock.RequestExecutor{}
+	assert.PanicsWithValue(t, "epccmock: RequestExecutor.DoRequest called but DoRequestFunc is not set", func() {
+		executor.DoRequest("GET", "/v2/products", nil)
+	})
+
+	executor.DoRequestFunc = func(method string, path string, payload io.Reader) ([]byte, error) {
+		return nil, errors.New("error unavailable")
+	}
+	_, err = executor.DoRequest("GET", "/v2/products", nil)
+	assert.Equal(t, errors.New("error unavailable"), err)
+	assert.Equal(t, 2, executor.CallCount("DoRequest"))
+}
@@ -0,0 +1,56 @@
+// Package epccmock provides mocks of the epcc service interfaces, so code which depends on them
+// can be tested without a server.
+//
+// Each method of a mock calls the function field of the same name with a Func suffix,
+// and panics when it is not set. Calls are recorded in order.
+//
+//	products := &epccmock.ProductsService{
+//		GetBySKUFunc: func(sku string) (*epcc.ProductData, error) {
+//			return nil, &epcc.NotFoundError{Resource: "product", Key: "sku", Value: sku}
+//		},
+//	}
+//	err := importer.Run(products)
+//	calls := products.Calls()
+//
+// The mocks are generated from the interfaces in services.go by running go generate in the epcc package.
+package epccmock
+
+import "sync"
+
+// Call is a call made to a mock.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// recorder records the calls made to a mock. It is safe for concurrent use.
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *recorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns every call made to the mock in order.
+func (r *recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// CallCount returns how many times a method was called.
+func (r *recorder) CallCount(method string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	count := 0
+	for _, call := range r.calls {
+		if call.Method == method {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,151 @@
+// Package epccotel adapts OpenTelemetry tracer and meter providers to the tracing and metrics
+// hooks of an epcc client. It is its own module, so the client does not depend on OpenTelemetry.
+//
+//	client.Tracer = epccotel.NewTracer(tracerProvider)
+//	client.Metrics, err = epccotel.NewMetrics(meterProvider)
+package epccotel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Rosalita/go-epcc-client"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the tracer and meter used by the adapters.
+const InstrumentationName = "github.com/Rosalita/go-epcc-client"
+
+// Names of the metrics recorded by the adapter returned by NewMetrics.
+const (
+	MetricRequests = "epcc.requests"         // MetricRequests counts requests by the status of their last response.
+	MetricAttempts = "epcc.request.attempts" // MetricAttempts counts attempts at sending requests, including retries.
+	MetricRetries  = "epcc.request.retries"  // MetricRetries counts attempts which were retried because of their status.
+	MetricDuration = "epcc.request.duration" // MetricDuration is a histogram of request durations in seconds, including every retry.
+)
+
+// attributeErrorType holds the type of the error returned for a request which failed without a response.
+const attributeErrorType = "error.type"
+
+// NewTracer returns a tracer which starts client spans with a tracer provider,
+// or with the global tracer provider when provider is nil.
+func NewTracer(provider trace.TracerProvider) epcc.Tracer {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	return tracer{tracer: provider.Tracer(InstrumentationName)}
+}
+
+type tracer struct {
+	tracer trace.Tracer
+}
+
+func (t tracer) Start(ctx context.Context, name string, attributes ...epcc.Attribute) (context.Context, epcc.Span) {
+	ctx, s := t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(keyValues(attributes)...))
+	return ctx, span{span: s}
+}
+
+type span struct {
+	span trace.Span
+}
+
+func (s span) SetAttributes(attributes ...epcc.Attribute) {
+	s.span.SetAttributes(keyValues(attributes)...)
+}
+
+// RecordError records the error as an event and sets the status of the span to error.
+func (s span) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s span) End() {
+	s.span.End()
+}
+
+// keyValues converts the attributes of a span, values which are not strings, numbers or booleans are formatted.
+func keyValues(attributes []epcc.Attribute) []attribute.KeyValue {
+	keyValues := make([]attribute.KeyValue, len(attributes))
+	for i, a := range attributes {
+		switch value := a.Value.(type) {
+		case string:
+			keyValues[i] = attribute.String(a.Key, value)
+		case int:
+			keyValues[i] = attribute.Int(a.Key, value)
+		case int64:
+			keyValues[i] = attribute.Int64(a.Key, value)
+		case float64:
+			keyValues[i] = attribute.Float64(a.Key, value)
+		case bool:
+			keyValues[i] = attribute.Bool(a.Key, value)
+		default:
+			keyValues[i] = attribute.String(a.Key, fmt.Sprint(value))
+		}
+	}
+	return keyValues
+}
+
+// NewMetrics returns a metrics recorder which records with a meter provider,
+// or with the global meter provider when provider is nil.
+func NewMetrics(provider metric.MeterProvider) (epcc.MetricsRecorder, error) {
+	if provider == nil {
+		provider = otel.GetMeterProvider()
+	}
+	meter := provider.Meter(InstrumentationName)
+
+	var m metrics
+	var err error
+	if m.requests, err = meter.Int64Counter(MetricRequests, metric.WithDescription("Requests made by the client, by the status of their last response.")); err != nil {
+		return nil, err
+	}
+	if m.attempts, err = meter.Int64Counter(MetricAttempts, metric.WithDescription("Attempts at sending requests, including retries.")); err != nil {
+		return nil, err
+	}
+	if m.retries, err = meter.Int64Counter(MetricRetries, metric.WithDescription("Attempts which were retried because of their status.")); err != nil {
+		return nil, err
+	}
+	if m.duration, err = meter.Float64Histogram(MetricDuration, metric.WithDescription("Duration of requests, including every retry."), metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type metrics struct {
+	requests metric.Int64Counter
+	attempts metric.Int64Counter
+	retries  metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+func (m metrics) RecordAttempt(metrics epcc.AttemptMetrics) {
+	ctx := context.Background()
+	m.attempts.Add(ctx, 1, metric.WithAttributes(requestAttributes(metrics.Method, metrics.Endpoint, metrics.Status, nil)...))
+	if metrics.Retried {
+		m.retries.Add(ctx, 1, metric.WithAttributes(requestAttributes(metrics.Method, metrics.Endpoint, 0, nil)...))
+	}
+}
+
+func (m metrics) RecordRequest(metrics epcc.RequestMetrics) {
+	ctx := context.Background()
+	m.requests.Add(ctx, 1, metric.WithAttributes(requestAttributes(metrics.Method, metrics.Endpoint, metrics.Status, metrics.Err)...))
+	m.duration.Record(ctx, metrics.Duration.Seconds(), metric.WithAttributes(requestAttributes(metrics.Method, metrics.Endpoint, 0, nil)...))
+}
+
+// requestAttributes returns the attributes of a metric, the status is left out when it is 0
+// and the error type is only set when no response was received.
+func requestAttributes(method string, endpoint string, status int, err error) []attribute.KeyValue {
+	attributes := []attribute.KeyValue{
+		attribute.String(epcc.AttributeMethod, method),
+		attribute.String(epcc.AttributeRoute, endpoint),
+	}
+	if status != 0 {
+		attributes = append(attributes, attribute.Int(epcc.AttributeStatus, status))
+	} else if err != nil {
+		attributes = append(attributes, attribute.String(attributeErrorType, fmt.Sprintf("%T", err)))
+	}
+	return attributes
+}
@@ -0,0 +1,264 @@
+package epccotel_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/Rosalita/go-epcc-client/epccotel"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+// fakeSpan keeps what was recorded on a span, the noop span provides the rest of trace.Span.
+type fakeSpan struct {
+	tracenoop.Span
+	name       string
+	parent     string
+	kind       trace.SpanKind
+	attributes map[attribute.Key]attribute.Value
+	errs       []error
+	status     codes.Code
+	ended      bool
+}
+
+func (s *fakeSpan) SetAttributes(attributes ...attribute.KeyValue) {
+	for _, a := range attributes {
+		s.attributes[a.Key] = a.Value
+	}
+}
+
+func (s *fakeSpan) RecordError(err error, options ...trace.EventOption) { s.errs = append(s.errs, err) }
+func (s *fakeSpan) SetStatus(code codes.Code, description string)       { s.status = code }
+func (s *fakeSpan) End(options ...trace.SpanEndOption)                  { s.ended = true }
+
+// fakeTracerProvider keeps every span started by its tracers, with the name of its parent.
+type fakeTracerProvider struct {
+	tracenoop.TracerProvider
+	mu    sync.Mutex
+	spans []*fakeSpan
+}
+
+func (p *fakeTracerProvider) Tracer(name string, options ...trace.TracerOption) trace.Tracer {
+	return fakeTracer{provider: p}
+}
+
+type fakeTracer struct {
+	tracenoop.Tracer
+	provider *fakeTracerProvider
+}
+
+func (t fakeTracer) Start(ctx context.Context, name string, options ...trace.SpanStartOption) (context.Context, trace.Span) {
+	t.provider.mu.Lock()
+	defer t.provider.mu.Unlock()
+
+	config := trace.NewSpanStartConfig(options...)
+	span := &fakeSpan{name: name, kind: config.SpanKind(), attributes: map[attribute.Key]attribute.Value{}}
+	if parent, ok := trace.SpanFromContext(ctx).(*fakeSpan); ok {
+		span.parent = parent.name
+	}
+	span.SetAttributes(config.Attributes()...)
+	t.provider.spans = append(t.provider.spans, span)
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+// fakeMeterProvider adds up the measurements of its counters and histograms by name and attributes.
+type fakeMeterProvider struct {
+	metricnoop.MeterProvider
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+func (p *fakeMeterProvider) Meter(name string, options ...metric.MeterOption) metric.Meter {
+	return fakeMeter{provider: p}
+}
+
+func (p *fakeMeterProvider) add(name string, value float64, options []metric.AddOption) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	set := metric.NewAddConfig(options).Attributes()
+	p.values[name+" "+set.Encoded(attribute.DefaultEncoder())] += value
+}
+
+type fakeMeter struct {
+	metricnoop.Meter
+	provider *fakeMeterProvider
+}
+
+func (m fakeMeter) Int64Counter(name string, options ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	return fakeCounter{name: name, provider: m.provider}, nil
+}
+
+func (m fakeMeter) Float64Histogram(name string, options ...metric.Float64HistogramOption) (metric.Float64Histogram, error) {
+	return fakeHistogram{name: name, provider: m.provider}, nil
+}
+
+type fakeCounter struct {
+	metricnoop.Int64Counter
+	name     string
+	provider *fakeMeterProvider
+}
+
+func (c fakeCounter) Add(ctx context.Context, value int64, options ...metric.AddOption) {
+	c.provider.add(c.name, float64(value), options)
+}
+
+// fakeHistogram counts its observations rather than adding them up.
+type fakeHistogram struct {
+	metricnoop.Float64Histogram
+	name     string
+	provider *fakeMeterProvider
+}
+
+func (h fakeHistogram) Record(ctx context.Context, value float64, options ...metric.RecordOption) {
+	set := metric.NewRecordConfig(options).Attributes()
+	h.provider.add(h.name, 1, []metric.AddOption{metric.WithAttributeSet(set)})
+}
+
+// fakeStore fails the first request to /v2/retry with a 503.
+func fakeStore() *httptest.Server {
+	var retried bool
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/oauth/access_token":
+			rw.Write([]byte(`{"access_token":"token"}`))
+		case "/v2/retry":
+			if !retried {
+				retried = true
+				rw.WriteHeader(503)
+				return
+			}
+			rw.Write([]byte(`{}`))
+		default:
+			rw.WriteHeader(404)
+			rw.Write([]byte(`{"errors":[{"status":404,"title":"Not Found"}]}`))
+		}
+	}))
+}
+
+func newClient(url string) *epcc.Client {
+	return epcc.NewClient(epcc.ClientOptions{
+		BaseURL:           url,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 100 * time.Millisecond,
+		ClientID:          "clientID",
+		ClientSecret:      "clientSecret",
+	})
+}
+
+func TestNewTracer(t *testing.T) {
+	server := fakeStore()
+	defer server.Close()
+
+	provider := &fakeTracerProvider{}
+	client := newClient(server.URL)
+	client.Tracer = epccotel.NewTracer(provider)
+
+	assert.Nil(t, client.Authenticate())
+	_, err := client.DoRequest("GET", "/v2/retry", nil)
+	assert.Nil(t, err)
+	_, err = client.DoRequest("GET", "/v2/products/9d1c5e5a", nil)
+	assert.NotNil(t, err)
+
+	if assert.Equal(t, 6, len(provider.spans)) {
+		names := []string{epcc.SpanAuthenticate, epcc.SpanRequest, epcc.SpanAttempt, epcc.SpanAttempt, epcc.SpanRequest, epcc.SpanAttempt}
+		parents := []string{"", "", epcc.SpanRequest, epcc.SpanRequest, "", epcc.SpanRequest}
+		for i, span := range provider.spans {
+			assert.Equal(t, names[i], span.name)
+			assert.Equal(t, parents[i], span.parent)
+			assert.Equal(t, trace.SpanKindClient, span.kind)
+			assert.True(t, span.ended)
+		}
+
+		request := provider.spans[1]
+		assert.Equal(t, "GET", request.attributes[epcc.AttributeMethod].AsString())
+		assert.Equal(t, "/v2/retry", request.attributes[epcc.AttributeRoute].AsString())
+		assert.Equal(t, int64(200), request.attributes[epcc.AttributeStatus].AsInt64())
+		assert.Equal(t, codes.Unset, request.status)
+
+		retried := provider.spans[2]
+		assert.Equal(t, int64(1), retried.attributes[epcc.AttributeAttempt].AsInt64())
+		assert.Equal(t, int64(503), retried.attributes[epcc.AttributeStatus].AsInt64())
+
+		failed := provider.spans[4]
+		assert.Equal(t, "/v2/products/{id}", failed.attributes[epcc.AttributeRoute].AsString())
+		assert.Equal(t, codes.Error, failed.status)
+		assert.Equal(t, []error{err}, failed.errs)
+	}
+}
+
+func TestNewMetrics(t *testing.T) {
+	server := fakeStore()
+	defer server.Close()
+
+	provider := &fakeMeterProvider{values: map[string]float64{}}
+	metrics, err := epccotel.NewMetrics(provider)
+	assert.Nil(t, err)
+
+	client := newClient(server.URL)
+	client.Metrics = metrics
+
+	assert.Nil(t, client.Authenticate())
+	_, err = client.DoRequest("GET", "/v2/retry", nil)
+	assert.Nil(t, err)
+	_, err = client.DoRequest("GET", "/v2/products/9d1c5e5a", nil)
+	assert.NotNil(t, err)
+
+	failed := errors.New("connection refused")
+	client.Use(epcc.BeforeSend(func(req *http.Request) error { return failed }))
+	_, err = client.DoRequest("GET", "/v2/products", nil)
+	assert.Equal(t, failed, err)
+
+	expected := map[string]float64{
+		`epcc.requests http.request.method=POST,http.response.status_code=200,http.route=/oauth/access_token`:         1,
+		`epcc.request.attempts http.request.method=POST,http.response.status_code=200,http.route=/oauth/access_token`: 1,
+		`epcc.request.duration http.request.method=POST,http.route=/oauth/access_token`:                               1,
+
+		`epcc.requests http.request.method=GET,http.response.status_code=200,http.route=/v2/retry`:         1,
+		`epcc.request.attempts http.request.method=GET,http.response.status_code=503,http.route=/v2/retry`: 1,
+		`epcc.request.attempts http.request.method=GET,http.response.status_code=200,http.route=/v2/retry`: 1,
+		`epcc.request.retries http.request.method=GET,http.route=/v2/retry`:                                1,
+		`epcc.request.duration http.request.method=GET,http.route=/v2/retry`:                               1,
+
+		`epcc.requests http.request.method=GET,http.response.status_code=404,http.route=/v2/products/{id}`:         1,
+		`epcc.request.attempts http.request.method=GET,http.response.status_code=404,http.route=/v2/products/{id}`: 1,
+		`epcc.request.duration http.request.method=GET,http.route=/v2/products/{id}`:                               1,
+
+		`epcc.requests error.type=*errors.errorString,http.request.method=GET,http.route=/v2/products`: 1,
+		`epcc.request.attempts http.request.method=GET,http.route=/v2/products`:                        1,
+		`epcc.request.duration http.request.method=GET,http.route=/v2/products`:                        1,
+	}
+	assert.Equal(t, expected, provider.values)
+}
+
+func TestKeyValues(t *testing.T) {
+	provider := &fakeTracerProvider{}
+	_, span := epccotel.NewTracer(provider).Start(context.Background(), "span",
+		epcc.Attribute{Key: "string", Value: "value"},
+		epcc.Attribute{Key: "int", Value: 1},
+		epcc.Attribute{Key: "int64", Value: int64(2)},
+		epcc.Attribute{Key: "float64", Value: 1.5},
+		epcc.Attribute{Key: "bool", Value: true},
+		epcc.Attribute{Key: "duration", Value: time.Second},
+	)
+	span.End()
+
+	attributes := provider.spans[0].attributes
+	assert.Equal(t, "value", attributes["string"].AsString())
+	assert.Equal(t, int64(1), attributes["int"].AsInt64())
+	assert.Equal(t, int64(2), attributes["int64"].AsInt64())
+	assert.Equal(t, 1.5, attributes["float64"].AsFloat64())
+	assert.Equal(t, true, attributes["bool"].AsBool())
+	assert.Equal(t, "1s", attributes["duration"].AsString())
+}
@@ -0,0 +1,312 @@
+package epcctest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Rosalita/go-epcc-client/internal/redact"
+	"gopkg.in/yaml.v3"
+)
+
+// RecordEnv is the environment variable which makes ModeFromEnv record cassettes instead of replaying them.
+const RecordEnv = "EPCCTEST_RECORD"
+
+// Redacted replaces secrets in recorded cassettes.
+const Redacted = redact.Redacted
+
+// Mode is whether a recorder records or replays interactions.
+type Mode int
+
+// Recorder modes.
+const (
+	Replay Mode = iota // Replay answers requests from the cassette and fails any request which was not recorded.
+	Record             // Record sends requests to the API and saves the interactions to the cassette.
+)
+
+// ModeFromEnv returns Record when EPCCTEST_RECORD is set, otherwise Replay.
+func ModeFromEnv() Mode {
+	if os.Getenv(RecordEnv) != "" {
+		return Record
+	}
+	return Replay
+}
+
+// Headers holding credentials, which are redacted when recorded.
+var redactedHeaders = []string{"Authorization", "EP-Account-Management-Authentication-Token"}
+
+// Cassette is a file of recorded interactions.
+type Cassette struct {
+	Interactions []Interaction `yaml:"interactions"`
+}
+
+// Interaction is a request and the response the API sent to it.
+type Interaction struct {
+	Request  RecordedRequest  `yaml:"request"`
+	Response RecordedResponse `yaml:"response"`
+}
+
+// RecordedRequest is a request with its credentials redacted.
+type RecordedRequest struct {
+	Method  string      `yaml:"method"`
+	Path    string      `yaml:"path"`
+	Query   string      `yaml:"query,omitempty"`
+	Headers http.Header `yaml:"headers,omitempty"`
+	Body    string      `yaml:"body,omitempty"`
+}
+
+// RecordedResponse is a response as it was received.
+type RecordedResponse struct {
+	StatusCode int         `yaml:"status_code"`
+	Headers    http.Header `yaml:"headers,omitempty"`
+	Body       string      `yaml:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper which records interactions with the API to a cassette file,
+// or replays them so tests can run offline. It is safe for concurrent use.
+//
+//	recorder, err := epcctest.NewRecorder("testdata/products.yaml", epcctest.ModeFromEnv())
+//	client.HTTPClient.Transport = recorder
+//	defer recorder.Stop()
+//
+// Requests are matched by method, path, query and body. Each recorded interaction is replayed once,
+// in the order it was recorded, so repeated requests can have different responses.
+type Recorder struct {
+	// Transport sends requests when recording, http.DefaultTransport is used when nil.
+	Transport http.RoundTripper
+
+	mode      Mode
+	path      string
+	mu        sync.Mutex
+	cassette  Cassette
+	used      []bool
+	unmatched []string
+}
+
+// NewRecorder returns a recorder for the cassette at path.
+// When replaying, the cassette must exist. When recording, it is written by Stop.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{mode: mode, path: path}
+	if mode == Record {
+		return r, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading cassette, record it by setting %s: %w", RecordEnv, err)
+	}
+	if err := yaml.Unmarshal(data, &r.cassette); err != nil {
+		return nil, fmt.Errorf("error reading cassette %s: %w", path, err)
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// Mode returns whether the recorder records or replays.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// RoundTrip records or replays a request.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+	recorded := recordRequest(req, body)
+
+	if r.mode == Record {
+		return r.record(req, body, recorded)
+	}
+	return r.replay(req, recorded)
+}
+
+// Stop writes the cassette when recording. When replaying, it returns an error
+// if any request was not matched, so failures are not hidden by code which ignores errors.
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mode == Replay {
+		if len(r.unmatched) > 0 {
+			return fmt.Errorf("error %d requests did not match the cassette %s: %s", len(r.unmatched), r.path, strings.Join(r.unmatched, ", "))
+		}
+		return nil
+	}
+
+	data, err := yaml.Marshal(r.cassette)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, data, 0644)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte, recorded RecordedRequest) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	// The body was read to record it, so the request is sent with a copy.
+	out := req.Clone(req.Context())
+	out.Body = ioutil.NopCloser(bytes.NewReader(body))
+	out.ContentLength = int64(len(body))
+
+	resp, err := transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    resp.Header.Clone(),
+			Body:       redactJSON(respBody),
+		},
+	})
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !matches(interaction.Request, recorded) {
+			continue
+		}
+		r.used[i] = true
+
+		response := interaction.Response
+		return &http.Response{
+			StatusCode:    response.StatusCode,
+			Status:        fmt.Sprintf("%d %s", response.StatusCode, http.StatusText(response.StatusCode)),
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        response.Headers.Clone(),
+			Body:          ioutil.NopCloser(strings.NewReader(response.Body)),
+			ContentLength: int64(len(response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	description := recorded.Method + " " + recorded.Path
+	if recorded.Query != "" {
+		description += "?" + recorded.Query
+	}
+	r.unmatched = append(r.unmatched, description)
+	return nil, fmt.Errorf("epcctest: no recorded interaction in %s matches %s with body %q", r.path, description, recorded.Body)
+}
+
+// recordRequest returns a request as it is saved to a cassette, with its credentials redacted.
+func recordRequest(req *http.Request, body []byte) RecordedRequest {
+	headers := req.Header.Clone()
+	for _, name := range redactedHeaders {
+		if headers.Get(name) != "" {
+			headers.Set(name, Redacted)
+		}
+	}
+
+	recorded := RecordedRequest{
+		Method:  req.Method,
+		Path:    req.URL.Path,
+		Query:   req.URL.Query().Encode(),
+		Headers: headers,
+		Body:    redactJSON(body),
+	}
+
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		if form, err := url.ParseQuery(recorded.Body); err == nil {
+			redacted := false
+			for key := range form {
+				if redact.Key(key) {
+					form.Set(key, Redacted)
+					redacted = true
+				}
+			}
+			if redacted {
+				recorded.Body = form.Encode()
+			}
+		}
+	}
+	return recorded
+}
+
+// matches reports whether a request is the same as a recorded request.
+// JSON bodies are compared without their whitespace.
+func matches(recorded RecordedRequest, req RecordedRequest) bool {
+	if recorded.Method != req.Method || recorded.Path != req.Path || recorded.Query != req.Query {
+		return false
+	}
+	return compactJSON(recorded.Body) == compactJSON(req.Body)
+}
+
+func compactJSON(body string) string {
+	var buffer bytes.Buffer
+	if err := json.Compact(&buffer, []byte(body)); err != nil {
+		return body
+	}
+	return buffer.String()
+}
+
+// redactJSON replaces the values of attributes which hold credentials, such as tokens and passwords,
+// anywhere in a JSON body. A body which is not JSON, or has nothing to redact, is returned as it is.
+func redactJSON(body []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil || !redactValue(value) {
+		return string(body)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return string(body)
+	}
+	return string(data)
+}
+
+// redactValue redacts the strings held by sensitive keys in a decoded JSON value, reporting whether any were.
+func redactValue(value interface{}) bool {
+	redacted := false
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if _, ok := field.(string); ok && redact.Key(key) {
+				value[key] = Redacted
+				redacted = true
+				continue
+			}
+			redacted = redactValue(field) || redacted
+		}
+	case []interface{}:
+		for _, item := range value {
+			redacted = redactValue(item) || redacted
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,184 @@
+package epcctest_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/Rosalita/go-epcc-client/epcctest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "epcctest")
+	assert.Equal(t, nil, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cassettes", "products.yaml")
+
+	// Record the interactions with a server.
+	server := epcctest.NewServer()
+	server.AddProduct(epcc.Product{Name: "Origami Crane", Slug: "crane", SKU: "CRN"})
+	server.InjectFault(epcctest.Fault{Method: "GET", Path: "/v2/products", StatusCode: 503, Times: 1})
+
+	recorder, err := epcctest.NewRecorder(path, epcctest.Record)
+	assert.Equal(t, nil, err)
+
+	client := epcc.NewClient(server.ClientOptions())
+	client.HTTPClient.Transport = recorder
+	assert.Equal(t, nil, client.Authenticate())
+
+	recordedProducts, err := epcc.Products.GetAll(client)
+	assert.Equal(t, nil, err)
+	created, err := epcc.Products.Create(client, &epcc.Product{Type: "product", Name: "Origami Frog", Slug: "frog", SKU: "FRG"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, recorder.Stop())
+	server.Close()
+
+	data, err := ioutil.ReadFile(path)
+	assert.Equal(t, nil, err)
+	cassette := string(data)
+	assert.Equal(t, false, strings.Contains(cassette, client.AccessToken()))
+
+	// Replay them without the server, using other credentials.
+	recorder, err = epcctest.NewRecorder(path, epcctest.Replay)
+	assert.Equal(t, nil, err)
+
+	options := server.ClientOptions()
+	options.ClientSecret = "another-secret"
+	client = epcc.NewClient(options)
+	client.HTTPClient.Transport = recorder
+	assert.Equal(t, nil, client.Authenticate())
+
+	products, err := epcc.Products.GetAll(client)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, recordedProducts, products)
+
+	replayed, err := epcc.Products.Create(client, &epcc.Product{Type: "product", Name: "Origami Frog", Slug: "frog", SKU: "FRG"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, created, replayed)
+	assert.Equal(t, nil, recorder.Stop())
+
+	// A request which was not recorded, or was already replayed, fails.
+	_, err = epcc.Products.Get(client, created.Data.ID)
+	assert.Contains(t, err.Error(), "no recorded interaction in "+path+" matches GET /v2/products/"+created.Data.ID)
+	_, err = epcc.Products.Create(client, &epcc.Product{Type: "product", Name: "Origami Frog", Slug: "frog", SKU: "FRG"})
+	assert.NotEqual(t, nil, err)
+
+	err = recorder.Stop()
+	assert.Contains(t, err.Error(), "error 2 requests did not match the cassette")
+}
+
+func TestRecorderMissingCassette(t *testing.T) {
+	_, err := epcctest.NewRecorder(filepath.Join("testdata", "missing.yaml"), epcctest.Replay)
+	assert.Contains(t, err.Error(), "error reading cassette, record it by setting EPCCTEST_RECORD")
+}
+
+func TestRecorderMatchesQueryAndJSON(t *testing.T) {
+	dir, err := ioutil.TempDir("", "epcctest")
+	assert.Equal(t, nil, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cassette.yaml")
+
+	cassette := `interactions:
+  - request:
+      method: GET
+      path: /v2/products
+      query: filter=eq%28sku%2CCRN%29&page%5Blimit%5D=1
+    response:
+      status_code: 200
+      body: '{"data":[{"id":"1","sku":"CRN"}]}'
+  - request:
+      method: POST
+      path: /v2/currencies
+      body: '{ "data": { "type": "currency", "code": "EUR" } }'
+    response:
+      status_code: 201
+      body: '{"data":{"id":"2","type":"currency","code":"EUR"}}'
+`
+	assert.Equal(t, nil, ioutil.WriteFile(path, []byte(cassette), 0644))
+
+	recorder, err := epcctest.NewRecorder(path, epcctest.Replay)
+	assert.Equal(t, nil, err)
+	client := epcc.NewClient(epcc.ClientOptions{BaseURL: "https://example.invalid/"})
+	client.HTTPClient.Transport = recorder
+
+	// The order of query parameters and the whitespace in JSON bodies do not matter.
+	body, err := client.DoRequest("GET", "/v2/products?page[limit]=1&filter=eq(sku,CRN)", nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{"data":[{"id":"1","sku":"CRN"}]}`, string(body))
+
+	body, err = client.DoRequest("POST", "/v2/currencies", strings.NewReader(`{"data":{"type":"currency","code":"EUR"}}`))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{"data":{"id":"2","type":"currency","code":"EUR"}}`, string(body))
+
+	assert.Equal(t, nil, recorder.Stop())
+}
+
+func TestRecorderRedactsCredentials(t *testing.T) {
+	server := epcctest.NewServer()
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "auth.yaml")
+
+	recorder, err := epcctest.NewRecorder(path, epcctest.Record)
+	assert.Equal(t, nil, err)
+
+	client := &http.Client{Transport: recorder}
+	form := url.Values{"client_id": {epcctest.ClientID}, "client_secret": {epcctest.ClientSecret}, "grant_type": {"client_credentials"}}
+	resp, err := client.PostForm(server.URL+"/oauth/access_token", form)
+	assert.Equal(t, nil, err)
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, recorder.Stop())
+
+	data, err := ioutil.ReadFile(path)
+	assert.Equal(t, nil, err)
+	cassette := string(data)
+	assert.Equal(t, false, strings.Contains(cassette, epcctest.ClientSecret))
+	assert.Equal(t, true, strings.Contains(cassette, "client_secret=REDACTED"))
+	assert.Equal(t, true, strings.Contains(string(body), "access_token"))
+	assert.Equal(t, true, strings.Contains(cassette, `"access_token":"REDACTED"`))
+}
+
+func TestRecorderRedactsAccountMemberTokens(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(200)
+		rw.Write([]byte(`{"data":[{"type":"account_management_authentication_token","account_id":"1","account_name":"Acme","token":"member-token","expires":"2026-10-20T00:00:00Z"}]}`))
+	}))
+	defer api.Close()
+	path := filepath.Join(t.TempDir(), "members.yaml")
+
+	recorder, err := epcctest.NewRecorder(path, epcctest.Record)
+	assert.Equal(t, nil, err)
+	client := epcc.NewClient(epcc.ClientOptions{BaseURL: api.URL})
+	client.HTTPClient.Transport = recorder
+	tokens, err := client.AuthenticateAccountMember("profile", "ron@swanson.com", "member-password")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "member-token", tokens[0].Token)
+	assert.Equal(t, nil, recorder.Stop())
+
+	data, err := ioutil.ReadFile(path)
+	assert.Equal(t, nil, err)
+	cassette := string(data)
+	assert.Equal(t, false, strings.Contains(cassette, "member-password"))
+	assert.Equal(t, false, strings.Contains(cassette, "member-token"))
+	assert.Equal(t, true, strings.Contains(cassette, `"password":"REDACTED"`))
+	assert.Equal(t, true, strings.Contains(cassette, "Acme"))
+
+	// The redacted request still matches when it is replayed.
+	recorder, err = epcctest.NewRecorder(path, epcctest.Replay)
+	assert.Equal(t, nil, err)
+	client = epcc.NewClient(epcc.ClientOptions{BaseURL: api.URL})
+	client.HTTPClient.Transport = recorder
+	tokens, err = client.AuthenticateAccountMember("profile", "ron@swanson.com", "another-password")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, epcctest.Redacted, tokens[0].Token)
+	assert.Equal(t, "1", tokens[0].AccountID)
+	assert.Equal(t, nil, recorder.Stop())
+}
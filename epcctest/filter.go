@@ -0,0 +1,204 @@
+package epcctest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// filter is a parsed filter query parameter, every expression must match.
+type filter []expression
+
+// expression is a single operator such as eq(sku,FRG).
+type expression struct {
+	operator  string
+	attribute string
+	values    []string
+}
+
+// operators are the filter operators the server supports.
+var operators = map[string]bool{"eq": true, "like": true, "in": true, "gt": true, "ge": true, "lt": true, "le": true}
+
+// parseFilter parses expressions such as eq(sku,FRG):gt(updated_at,2020-09-01T00:00:00Z).
+// Values containing commas, parentheses or spaces may be wrapped in double quotes.
+func parseFilter(text string) (filter, error) {
+	var f filter
+	rest := text
+
+	for rest != "" {
+		open := strings.Index(rest, "(")
+		if open < 0 {
+			return nil, fmt.Errorf("invalid filter %q", text)
+		}
+		operator := rest[:open]
+		if !operators[operator] {
+			return nil, fmt.Errorf("unknown filter operator %q", operator)
+		}
+
+		args, end, err := parseArgs(rest[open+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter %q: %s", text, err)
+		}
+		if len(args) < 2 || (operator != "in" && len(args) != 2) {
+			return nil, fmt.Errorf("invalid filter %q: %s needs an attribute and a value", text, operator)
+		}
+		f = append(f, expression{operator: operator, attribute: args[0], values: args[1:]})
+
+		rest = rest[open+1+end:]
+		if rest != "" {
+			if rest[0] != ':' {
+				return nil, fmt.Errorf("invalid filter %q", text)
+			}
+			rest = rest[1:]
+		}
+	}
+
+	return f, nil
+}
+
+// parseArgs parses comma separated arguments up to the closing parenthesis and returns
+// the arguments and the length of text they used, including the parenthesis.
+func parseArgs(text string) ([]string, int, error) {
+	var args []string
+	var arg strings.Builder
+	quoted := false
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quoted && c == '\\' && i+1 < len(text):
+			i++
+			arg.WriteByte(text[i])
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+			arg.WriteByte(c)
+		case c == ',':
+			args = append(args, arg.String())
+			arg.Reset()
+		case c == ')':
+			return append(args, arg.String()), i + 1, nil
+		default:
+			arg.WriteByte(c)
+		}
+	}
+
+	return nil, 0, fmt.Errorf("missing )")
+}
+
+func (f filter) apply(items []map[string]interface{}) []map[string]interface{} {
+	matched := []map[string]interface{}{}
+	for _, item := range items {
+		if f.matches(item) {
+			matched = append(matched, item)
+		}
+	}
+	return matched
+}
+
+func (f filter) matches(item map[string]interface{}) bool {
+	for _, e := range f {
+		if !e.matches(attribute(item, e.attribute)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e expression) matches(value string) bool {
+	switch e.operator {
+	case "eq":
+		return strings.EqualFold(value, e.values[0])
+	case "in":
+		for _, v := range e.values {
+			if strings.EqualFold(value, v) {
+				return true
+			}
+		}
+		return false
+	case "like":
+		return like(strings.ToLower(value), strings.ToLower(e.values[0]))
+	}
+
+	compared := compare(value, e.values[0])
+	switch e.operator {
+	case "gt":
+		return compared > 0
+	case "ge":
+		return compared >= 0
+	case "lt":
+		return compared < 0
+	}
+	return compared <= 0
+}
+
+// attribute returns an attribute of a resource as text, timestamps are found in meta.
+func attribute(item map[string]interface{}, name string) string {
+	value, ok := item[name]
+	if !ok && (name == "created_at" || name == "updated_at") {
+		meta, _ := item["meta"].(map[string]interface{})
+		timestamps, _ := meta["timestamps"].(map[string]interface{})
+		value = timestamps[name]
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// compare compares values as numbers or times when both are, otherwise as text.
+func compare(a string, b string) int {
+	if s, errA := time.Parse(time.RFC3339Nano, a); errA == nil {
+		if t, errB := time.Parse(time.RFC3339Nano, b); errB == nil {
+			switch {
+			case s.Before(t):
+				return -1
+			case s.After(t):
+				return 1
+			}
+			return 0
+		}
+	}
+
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(a, b)
+}
+
+// like matches a value against a pattern where * matches any text.
+func like(value string, pattern string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return value == pattern
+	}
+
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(value, part)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(part):]
+	}
+	return strings.HasSuffix(value, parts[len(parts)-1])
+}
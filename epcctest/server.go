@@ -0,0 +1,584 @@
+// Package epcctest provides an in-memory EPCC server for testing code which uses the epcc client.
+//
+// The server keeps resources in memory, issues access tokens for its client credentials,
+// generates IDs and timestamps, and supports pagination, filters and the API's error bodies.
+// Faults can be injected to test how code handles failures.
+// A Recorder records interactions with a real store to a cassette file and replays them offline.
+//
+//	server := epcctest.NewServer()
+//	defer server.Close()
+//
+//	client := server.Client()
+//	products, err := epcc.Products.GetAll(client)
+package epcctest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Rosalita/go-epcc-client"
+)
+
+// Credentials accepted by a new server.
+const (
+	ClientID     = "epcctest-client-id"
+	ClientSecret = "epcctest-client-secret"
+)
+
+// Page sizes used when listing resources.
+const (
+	DefaultPageLimit = 25
+	MaxPageLimit     = 100
+)
+
+// Server is an in-memory EPCC server. It is safe for concurrent use.
+type Server struct {
+	URL string
+
+	// Now returns the time used for timestamps, it can be replaced to control them.
+	Now func() time.Time
+
+	server      *httptest.Server
+	mu          sync.Mutex
+	tokens      map[string]bool
+	collections map[string]*collection
+	faults      []*Fault
+	requests    []Request
+}
+
+// Request is a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Header http.Header
+	Body   string
+}
+
+// Fault makes the server fail matching requests instead of handling them.
+// An empty Method or Path matches any request, Path matches requests with the path as a prefix.
+// A fault without a StatusCode only delays matching requests, which are then handled as usual.
+type Fault struct {
+	Method     string
+	Path       string
+	StatusCode int           // StatusCode is the status code of the response, such as 429 or 503.
+	Body       string        // Body is the body of the response, an error body for the status code is sent when empty.
+	Delay      time.Duration // Delay is how long to wait before responding.
+	Times      int           // Times is how many requests fail, 0 fails every matching request.
+
+	hits int
+}
+
+// NewServer starts a server with the products and currencies services.
+func NewServer() *Server {
+	s := &Server{
+		Now:         time.Now,
+		tokens:      map[string]bool{},
+		collections: map[string]*collection{},
+	}
+
+	s.Register("currencies", "currency", []string{"code"})
+	s.Register("products", "product", []string{"sku", "slug"})
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// ClientOptions returns options which configure a client to use the server.
+func (s *Server) ClientOptions() epcc.ClientOptions {
+	return epcc.ClientOptions{
+		BaseURL:           s.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 100 * time.Millisecond,
+		ClientID:          ClientID,
+		ClientSecret:      ClientSecret,
+	}
+}
+
+// Client returns a client which is authenticated with the server.
+func (s *Server) Client() *epcc.Client {
+	client := epcc.NewClient(s.ClientOptions())
+	if err := client.Authenticate(); err != nil {
+		panic(fmt.Sprintf("epcctest: authenticating with the test server failed: %s", err))
+	}
+	return client
+}
+
+// Register adds a service at /v2/{path} which stores resources of a type.
+// Creating or updating a resource fails when any of the unique fields is the same as another resource's.
+func (s *Server) Register(path string, resourceType string, unique []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.collections[path] = &collection{
+		resourceType: resourceType,
+		unique:       unique,
+		items:        map[string]map[string]interface{}{},
+	}
+}
+
+// AddProduct stores a product as if it were created, and returns it with its ID and timestamps.
+func (s *Server) AddProduct(product epcc.Product) epcc.Product {
+	if product.Type == "" {
+		product.Type = "product"
+	}
+	var added epcc.Product
+	s.add("products", product, &added)
+	return added
+}
+
+// AddCurrency stores a currency as if it were created, and returns it with its ID and timestamps.
+func (s *Server) AddCurrency(currency epcc.Currency) epcc.Currency {
+	if currency.Type == "" {
+		currency.Type = "currency"
+	}
+	var added epcc.Currency
+	s.add("currencies", currency, &added)
+	return added
+}
+
+// Products returns the stored products in the order they were created.
+func (s *Server) Products() []epcc.Product {
+	var products []epcc.Product
+	s.list("products", &products)
+	return products
+}
+
+// Currencies returns the stored currencies in the order they were created.
+func (s *Server) Currencies() []epcc.Currency {
+	var currencies []epcc.Currency
+	s.list("currencies", &currencies)
+	return currencies
+}
+
+// InjectFault makes matching requests fail, faults are checked in the order they were injected.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns every request received by the server.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) add(path string, resource interface{}, added interface{}) {
+	fields := toFields(resource)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, apiError := s.collections[path].create(fields, s.Now())
+	if apiError != nil {
+		panic(fmt.Sprintf("epcctest: adding to %s failed: %s", path, apiError.Errors[0].Detail))
+	}
+
+	fromFields(item, added)
+}
+
+func (s *Server) list(path string, resources interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fromFields(s.collections[path].all(), resources)
+}
+
+func (s *Server) serveHTTP(rw http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: req.Method, Path: req.URL.Path, Query: req.URL.RawQuery, Header: req.Header.Clone(), Body: string(body)})
+	fault := s.fault(req)
+	s.mu.Unlock()
+
+	if fault != nil {
+		time.Sleep(fault.Delay)
+	}
+
+	if fault != nil && fault.StatusCode != 0 {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(fault.StatusCode)
+		if fault.Body != "" {
+			rw.Write([]byte(fault.Body))
+		} else {
+			writeJSON(rw, newAPIError(fault.StatusCode, http.StatusText(fault.StatusCode), "fault injected by epcctest"))
+		}
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.URL.Path == "/oauth/access_token" {
+		s.authenticate(rw, req, string(body))
+		return
+	}
+
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !s.tokens[token] {
+		respondError(rw, http.StatusUnauthorized, "Unauthorized", "a valid access token is required")
+		return
+	}
+
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] != "v2" || s.collections[parts[1]] == nil {
+		respondError(rw, http.StatusNotFound, "Not Found", "the requested endpoint does not exist")
+		return
+	}
+	c := s.collections[parts[1]]
+
+	id := ""
+	if len(parts) == 3 {
+		id = parts[2]
+	}
+
+	switch {
+	case req.Method == "GET" && id == "":
+		s.handleList(rw, req, c)
+	case req.Method == "GET":
+		item, apiError := c.get(id)
+		respond(rw, http.StatusOK, item, apiError)
+	case req.Method == "POST" && id == "":
+		fields, apiError := decodeData(string(body), c.resourceType)
+		if apiError == nil {
+			fields, apiError = c.create(fields, s.Now())
+		}
+		respond(rw, http.StatusCreated, fields, apiError)
+	case req.Method == "PUT" && id != "":
+		fields, apiError := decodeData(string(body), c.resourceType)
+		if apiError == nil {
+			fields, apiError = c.update(id, fields, s.Now())
+		}
+		respond(rw, http.StatusOK, fields, apiError)
+	case req.Method == "DELETE" && id != "":
+		if apiError := c.delete(id); apiError != nil {
+			respond(rw, 0, nil, apiError)
+			return
+		}
+		rw.WriteHeader(http.StatusNoContent)
+	default:
+		respondError(rw, http.StatusMethodNotAllowed, "Method Not Allowed", "the method is not allowed for this endpoint")
+	}
+}
+
+// fault returns the first injected fault which matches a request and counts the hit.
+func (s *Server) fault(req *http.Request) *Fault {
+	for _, fault := range s.faults {
+		if fault.Method != "" && fault.Method != req.Method {
+			continue
+		}
+		if !strings.HasPrefix(req.URL.Path, fault.Path) {
+			continue
+		}
+		if fault.Times > 0 && fault.hits >= fault.Times {
+			continue
+		}
+		fault.hits++
+		return fault
+	}
+	return nil
+}
+
+func (s *Server) authenticate(rw http.ResponseWriter, req *http.Request, body string) {
+	form, _ := url.ParseQuery(body)
+	if req.Method != "POST" || form.Get("grant_type") != "client_credentials" {
+		respondError(rw, http.StatusBadRequest, "Bad Request", "grant_type must be client_credentials")
+		return
+	}
+	if form.Get("client_id") != ClientID || form.Get("client_secret") != ClientSecret {
+		respondError(rw, http.StatusUnauthorized, "Unauthorized", "invalid client credentials")
+		return
+	}
+
+	token := newID(20)
+	s.tokens[token] = true
+
+	now := s.Now()
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+	writeJSON(rw, map[string]interface{}{
+		"expires":      now.Add(time.Hour).Unix(),
+		"expires_in":   3600,
+		"identifier":   "client_credentials",
+		"token_type":   "Bearer",
+		"access_token": token,
+	})
+}
+
+func (s *Server) handleList(rw http.ResponseWriter, req *http.Request, c *collection) {
+	query := req.URL.Query()
+
+	limit := DefaultPageLimit
+	if value := query.Get("page[limit]"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > MaxPageLimit {
+			respondError(rw, http.StatusBadRequest, "Bad Request", fmt.Sprintf("page[limit] must be between 1 and %d", MaxPageLimit))
+			return
+		}
+		limit = parsed
+	}
+
+	offset := 0
+	if value := query.Get("page[offset]"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			respondError(rw, http.StatusBadRequest, "Bad Request", "page[offset] must not be negative")
+			return
+		}
+		offset = parsed
+	}
+
+	items := c.all()
+	if expression := query.Get("filter"); expression != "" {
+		filter, err := parseFilter(expression)
+		if err != nil {
+			respondError(rw, http.StatusBadRequest, "Bad Request", err.Error())
+			return
+		}
+		items = filter.apply(items)
+	}
+
+	total := len(items)
+	end := offset + limit
+	if offset > total {
+		offset = total
+	}
+	if end > total {
+		end = total
+	}
+
+	pages := (total + limit - 1) / limit
+	if pages == 0 {
+		pages = 1
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+	writeJSON(rw, map[string]interface{}{
+		"data": items[offset:end],
+		"meta": map[string]interface{}{
+			"page": map[string]interface{}{
+				"limit":   limit,
+				"offset":  offset,
+				"current": offset/limit + 1,
+				"total":   pages,
+			},
+			"results": map[string]interface{}{
+				"total": total,
+			},
+		},
+	})
+}
+
+// collection stores the resources of a service as decoded JSON.
+type collection struct {
+	resourceType string
+	unique       []string
+	items        map[string]map[string]interface{}
+	order        []string
+}
+
+func (c *collection) all() []map[string]interface{} {
+	items := make([]map[string]interface{}, 0, len(c.order))
+	for _, id := range c.order {
+		items = append(items, c.items[id])
+	}
+	return items
+}
+
+func (c *collection) get(id string) (map[string]interface{}, *epcc.APIError) {
+	item, ok := c.items[id]
+	if !ok {
+		return nil, newAPIError(http.StatusNotFound, "Not Found", fmt.Sprintf("no %s found with id %s", c.resourceType, id))
+	}
+	return item, nil
+}
+
+func (c *collection) create(fields map[string]interface{}, now time.Time) (map[string]interface{}, *epcc.APIError) {
+	if apiError := c.checkUnique("", fields); apiError != nil {
+		return nil, apiError
+	}
+
+	id := newUUID()
+	fields["id"] = id
+	fields["type"] = c.resourceType
+	setTimestamps(fields, now, true)
+
+	c.items[id] = fields
+	c.order = append(c.order, id)
+	return fields, nil
+}
+
+// update changes the fields which are sent, like the API.
+func (c *collection) update(id string, fields map[string]interface{}, now time.Time) (map[string]interface{}, *epcc.APIError) {
+	item, apiError := c.get(id)
+	if apiError != nil {
+		return nil, apiError
+	}
+	if sentID, ok := fields["id"]; ok && sentID != id {
+		return nil, newAPIError(http.StatusConflict, "Conflict", "the id in the body does not match the id in the path")
+	}
+	if apiError := c.checkUnique(id, fields); apiError != nil {
+		return nil, apiError
+	}
+
+	for field, value := range fields {
+		if field == "meta" || field == "links" {
+			continue
+		}
+		item[field] = value
+	}
+	setTimestamps(item, now, false)
+	return item, nil
+}
+
+func (c *collection) delete(id string) *epcc.APIError {
+	item, apiError := c.get(id)
+	if apiError != nil {
+		return apiError
+	}
+	if c.resourceType == "currency" && item["default"] == true {
+		return newAPIError(http.StatusBadRequest, "Cannot delete default currency", "Make another currency default before removing")
+	}
+
+	delete(c.items, id)
+	for i := range c.order {
+		if c.order[i] == id {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (c *collection) checkUnique(id string, fields map[string]interface{}) *epcc.APIError {
+	for _, field := range c.unique {
+		value, ok := fields[field]
+		if !ok || value == "" {
+			continue
+		}
+		for otherID, other := range c.items {
+			if otherID != id && other[field] == value {
+				if c.resourceType == "currency" {
+					return newAPIError(http.StatusBadRequest, "Currency already exists", "The specified currency code already exists for this store")
+				}
+				return newAPIError(http.StatusConflict, "Duplicate", fmt.Sprintf("a %s with %s %v already exists", c.resourceType, field, value))
+			}
+		}
+	}
+	return nil
+}
+
+// setTimestamps sets meta.timestamps, created_at is only set when a resource is created.
+func setTimestamps(fields map[string]interface{}, now time.Time, created bool) {
+	meta, _ := fields["meta"].(map[string]interface{})
+	if meta == nil {
+		meta = map[string]interface{}{}
+	}
+	timestamps, _ := meta["timestamps"].(map[string]interface{})
+	if timestamps == nil || created {
+		timestamps = map[string]interface{}{}
+	}
+
+	stamp := now.UTC().Format("2006-01-02T15:04:05.000Z07:00")
+	if created {
+		timestamps["created_at"] = stamp
+	}
+	timestamps["updated_at"] = stamp
+	meta["timestamps"] = timestamps
+	fields["meta"] = meta
+}
+
+// decodeData decodes the data of a request body, which must be of the type of the collection.
+func decodeData(body string, resourceType string) (map[string]interface{}, *epcc.APIError) {
+	var request struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(body), &request); err != nil || request.Data == nil {
+		return nil, newAPIError(http.StatusBadRequest, "Bad Request", "the body must be a JSON object with data")
+	}
+	if request.Data["type"] != resourceType {
+		return nil, newAPIError(http.StatusUnprocessableEntity, "Failed Validation", fmt.Sprintf("data.type must be %s", resourceType))
+	}
+	return request.Data, nil
+}
+
+func respond(rw http.ResponseWriter, status int, item map[string]interface{}, apiError *epcc.APIError) {
+	rw.Header().Set("Content-Type", "application/json")
+	if apiError != nil {
+		rw.WriteHeader(apiError.StatusCode)
+		writeJSON(rw, apiError)
+		return
+	}
+	rw.WriteHeader(status)
+	writeJSON(rw, map[string]interface{}{"data": item})
+}
+
+func respondError(rw http.ResponseWriter, status int, title string, detail string) {
+	respond(rw, 0, nil, newAPIError(status, title, detail))
+}
+
+func newAPIError(status int, title string, detail string) *epcc.APIError {
+	return &epcc.APIError{
+		StatusCode: status,
+		Errors:     []epcc.ErrorDetail{{Status: status, Title: title, Detail: detail}},
+	}
+}
+
+func writeJSON(rw http.ResponseWriter, v interface{}) {
+	json.NewEncoder(rw).Encode(v)
+}
+
+// toFields encodes a resource as decoded JSON.
+func toFields(resource interface{}) map[string]interface{} {
+	data, _ := json.Marshal(resource)
+	var fields map[string]interface{}
+	json.Unmarshal(data, &fields)
+	return fields
+}
+
+// fromFields decodes JSON values into v.
+func fromFields(fields interface{}, v interface{}) {
+	data, _ := json.Marshal(fields)
+	json.Unmarshal(data, v)
+}
+
+// newUUID returns a random version 4 UUID.
+func newUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	h := hex.EncodeToString(b)
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32]
+}
+
+// newID returns a random hex string of n bytes.
+func newID(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
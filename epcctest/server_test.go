@@ -0,0 +1,205 @@
+package epcctest_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/Rosalita/go-epcc-client/epcctest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerProducts(t *testing.T) {
+	server := epcctest.NewServer()
+	defer server.Close()
+
+	now := time.Date(2020, 9, 1, 10, 0, 0, 0, time.UTC)
+	server.Now = func() time.Time { return now }
+
+	client := server.Client()
+
+	created, err := epcc.Products.Create(client, &epcc.Product{Type: "product", Name: "Origami Frog", Slug: "frog", SKU: "FRG"})
+	assert.Equal(t, nil, err)
+	assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, created.Data.ID)
+	assert.Equal(t, "2020-09-01T10:00:00.000Z", created.Data.Meta.Timestamps.CreatedAt)
+
+	now = now.Add(time.Hour)
+	updated, err := epcc.Products.Update(client, &epcc.Product{ID: created.Data.ID, Type: "product", Name: "Green Frog", Slug: "frog", SKU: "FRG"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Green Frog", updated.Data.Name)
+	assert.Equal(t, "2020-09-01T10:00:00.000Z", updated.Data.Meta.Timestamps.CreatedAt)
+	assert.Equal(t, "2020-09-01T11:00:00.000Z", updated.Data.Meta.Timestamps.UpdatedAt)
+
+	got, err := epcc.Products.Get(client, created.Data.ID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Green Frog", got.Data.Name)
+
+	_, err = epcc.Products.Create(client, &epcc.Product{Type: "product", Name: "Copy", Slug: "copy", SKU: "FRG"})
+	assert.Equal(t, &epcc.APIError{
+		StatusCode: 409,
+		Errors:     []epcc.ErrorDetail{{Status: 409, Title: "Duplicate", Detail: "a product with sku FRG already exists"}},
+	}, err)
+
+	assert.Equal(t, nil, epcc.Products.Delete(client, created.Data.ID))
+	_, err = epcc.Products.Get(client, created.Data.ID)
+	assert.Equal(t, true, epcc.IsNotFound(err))
+	assert.Equal(t, 0, len(server.Products()))
+}
+
+func TestServerCurrencies(t *testing.T) {
+	server := epcctest.NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	gbp := server.AddCurrency(epcc.Currency{Code: "GBP", Format: "£{price}", Default: true})
+	assert.Equal(t, "currency", gbp.Type)
+
+	_, err := epcc.Currencies.Create(client, &epcc.Currency{Type: "currency", Code: "GBP", Format: "£{price}"})
+	assert.Equal(t, &epcc.APIError{
+		StatusCode: 400,
+		Errors:     []epcc.ErrorDetail{{Status: 400, Title: "Currency already exists", Detail: "The specified currency code already exists for this store"}},
+	}, err)
+
+	err = epcc.Currencies.Delete(client, gbp.ID)
+	assert.Equal(t, &epcc.APIError{
+		StatusCode: 400,
+		Errors:     []epcc.ErrorDetail{{Status: 400, Title: "Cannot delete default currency", Detail: "Make another currency default before removing"}},
+	}, err)
+
+	currencies, err := epcc.Currencies.GetAll(client)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []epcc.Currency{gbp}, currencies.Data)
+}
+
+func TestServerFiltersAndPagination(t *testing.T) {
+	server := epcctest.NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	start := time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 30; i++ {
+		now := start.Add(time.Duration(i) * time.Hour)
+		server.Now = func() time.Time { return now }
+		server.AddProduct(epcc.Product{Name: fmt.Sprintf("Origami %d", i), Slug: fmt.Sprintf("origami-%d", i), SKU: fmt.Sprintf("SKU-%d", i), Status: "live"})
+	}
+	server.AddProduct(epcc.Product{Name: "Paper Crane, Large", Slug: "crane", SKU: "CRN", Status: "draft"})
+
+	tests := []struct {
+		filter epcc.Filter
+		count  int
+	}{
+		{epcc.Filter{}.Eq("sku", "sku-3"), 1},
+		{epcc.Filter{}.In("sku", "SKU-1", "SKU-2", "CRN", "MISSING"), 3},
+		{epcc.Filter{}.Like("name", "origami*"), 30},
+		{epcc.Filter{}.Eq("name", "Paper Crane, Large"), 1},
+		{epcc.Filter{}.Eq("status", "live").UpdatedSince(start.Add(27 * time.Hour)), 2},
+		{epcc.Filter{}.Ge("updated_at", "2020-09-02T05:00:00Z").Lt("updated_at", "2020-09-02T06:00:00Z"), 2},
+	}
+
+	for _, test := range tests {
+		products, err := epcc.Products.GetAllFiltered(client, test.filter)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, test.count, len(products.Data), test.filter.String())
+	}
+
+	product, err := epcc.Products.GetBySKU(client, "CRN")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Paper Crane, Large", product.Data.Name)
+
+	body, err := client.DoRequest("GET", "/v2/products?page[limit]=10&page[offset]=20", nil)
+	assert.Equal(t, nil, err)
+
+	var page struct {
+		Data []epcc.Product `json:"data"`
+		Meta struct {
+			Page    map[string]int `json:"page"`
+			Results map[string]int `json:"results"`
+		} `json:"meta"`
+	}
+	assert.Equal(t, nil, json.Unmarshal(body, &page))
+	assert.Equal(t, 10, len(page.Data))
+	assert.Equal(t, "SKU-20", page.Data[0].SKU)
+	assert.Equal(t, map[string]int{"limit": 10, "offset": 20, "current": 3, "total": 4}, page.Meta.Page)
+	assert.Equal(t, map[string]int{"total": 31}, page.Meta.Results)
+
+	_, err = client.DoRequest("GET", "/v2/products?filter=near(sku,FRG)", nil)
+	assert.Equal(t, &epcc.APIError{
+		StatusCode: 400,
+		Errors:     []epcc.ErrorDetail{{Status: 400, Title: "Bad Request", Detail: `unknown filter operator "near"`}},
+	}, err)
+}
+
+func TestServerFaults(t *testing.T) {
+	server := epcctest.NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	// The client retries a 503, so a single fault is recovered from.
+	server.InjectFault(epcctest.Fault{Method: "GET", Path: "/v2/products", StatusCode: 503, Times: 1})
+	_, err := epcc.Products.GetAll(client)
+	assert.Equal(t, nil, err)
+
+	server.InjectFault(epcctest.Fault{Method: "POST", Path: "/v2/currencies", StatusCode: 422, Body: `{"errors":[{"status":422,"title":"Failed Validation","detail":"bad format"}]}`})
+	_, err = epcc.Currencies.Create(client, &epcc.Currency{Type: "currency", Code: "EUR"})
+	assert.Equal(t, &epcc.APIError{
+		StatusCode: 422,
+		Errors:     []epcc.ErrorDetail{{Status: 422, Title: "Failed Validation", Detail: "bad format"}},
+	}, err)
+
+	server.InjectFault(epcctest.Fault{Path: "/v2/", StatusCode: 429})
+	_, err = epcc.Currencies.GetAll(client)
+	assert.Equal(t, errors.New("retry timeout error"), err)
+
+	server.ClearFaults()
+	_, err = epcc.Currencies.GetAll(client)
+	assert.Equal(t, nil, err)
+
+	requests := server.Requests()
+	assert.Equal(t, "/oauth/access_token", requests[0].Path)
+	assert.Equal(t, "GET", requests[len(requests)-1].Method)
+
+	// A fault with only a delay is handled as usual once the delay is over.
+	server.InjectFault(epcctest.Fault{Method: "GET", Path: "/v2/currencies", Delay: 50 * time.Millisecond, Times: 1})
+	start := time.Now()
+	_, err = epcc.Currencies.GetAll(client)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, time.Since(start) >= 50*time.Millisecond)
+}
+
+func TestServerAuthentication(t *testing.T) {
+	server := epcctest.NewServer()
+	defer server.Close()
+
+	options := server.ClientOptions()
+	options.ClientSecret = "wrong"
+	client := epcc.NewClient(options)
+	assert.Equal(t, errors.New("error: unexpected status 401 Unauthorized"), client.Authenticate())
+
+	_, err := epcc.Products.GetAll(client)
+	assert.Equal(t, &epcc.APIError{
+		StatusCode: 401,
+		Errors:     []epcc.ErrorDetail{{Status: 401, Title: "Unauthorized", Detail: "a valid access token is required"}},
+	}, err)
+}
+
+func TestServerRegister(t *testing.T) {
+	server := epcctest.NewServer()
+	defer server.Close()
+	server.Register("integrations", "integration", nil)
+	client := server.Client()
+
+	created, err := epcc.Integrations.Create(client, &epcc.Integration{
+		Type:            "integration",
+		Name:            "Order webhook",
+		IntegrationType: epcc.IntegrationTypeWebhook,
+		Enabled:         true,
+	})
+	assert.Equal(t, nil, err)
+
+	got, err := epcc.Integrations.Get(client, created.Data.ID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Order webhook", got.Data.Name)
+}
@@ -0,0 +1,105 @@
+package epcc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// APIError is returned when the API responds with a status code which is not ok.
+// Errors holds the errors decoded from the body of the response.
+type APIError struct {
+	StatusCode int           `json:"-"`
+	Errors     []ErrorDetail `json:"errors"`
+}
+
+// Error returns the status code of the response.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("status code %d is not ok", e.StatusCode)
+}
+
+// ErrorDetail is a single error reported by the API or by client side validation.
+type ErrorDetail struct {
+	Status int    `json:"status,omitempty"`
+	Title  string `json:"title"`
+	Detail string `json:"detail,omitempty"`
+	Source string `json:"source,omitempty"`
+}
+
+// UnmarshalJSON decodes an error, accepting a status sent as either a number or a string.
+func (e *ErrorDetail) UnmarshalJSON(data []byte) error {
+	type errorDetail ErrorDetail
+	var decoded struct {
+		errorDetail
+		Status json.RawMessage `json:"status,omitempty"`
+	}
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*e = ErrorDetail(decoded.errorDetail)
+	status := strings.Trim(string(decoded.Status), `"`)
+	if status != "" && status != "null" {
+		e.Status, _ = strconv.Atoi(status)
+	}
+	return nil
+}
+
+// ValidationError is returned when a payload fails client side validation before it is sent.
+// It has the same shape as APIError, with one ErrorDetail for each invalid field.
+type ValidationError struct {
+	Errors []ErrorDetail `json:"errors"`
+}
+
+// Error lists every invalid field.
+func (e *ValidationError) Error() string {
+	details := make([]string, len(e.Errors))
+	for i, detail := range e.Errors {
+		details[i] = detail.Detail
+	}
+	return fmt.Sprintf("validation failed: %s", strings.Join(details, "; "))
+}
+
+// add records an invalid field.
+func (e *ValidationError) add(source string, format string, args ...interface{}) {
+	e.Errors = append(e.Errors, ErrorDetail{
+		Status: 422,
+		Title:  "Failed Validation",
+		Detail: source + " " + fmt.Sprintf(format, args...),
+		Source: source,
+	})
+}
+
+// errOrNil returns the validation error if any field is invalid.
+func (e *ValidationError) errOrNil() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+// NotFoundError is returned when a lookup by a key other than ID finds nothing.
+type NotFoundError struct {
+	Resource string
+	Key      string
+	Value    string
+}
+
+// Error describes the resource which was not found.
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("error %s with %s %q not found", e.Resource, e.Key, e.Value)
+}
+
+// IsNotFound reports whether an error is a *NotFoundError or an *APIError with status code 404.
+func IsNotFound(err error) bool {
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) {
+		return true
+	}
+	var apiError *APIError
+	return errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,141 @@
+package epcc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// An extension is a Go struct describing the custom flow fields of a core resource.
+// Each struct field tagged `flow:"slug"` maps onto the flow field with that slug,
+// and `flow:"slug,omitempty"` leaves the field out of requests when it has its zero value.
+//
+//	type ProductExtension struct {
+//		Colour    string `flow:"colour"`
+//		Warranty  int    `flow:"warranty_months,omitempty"`
+//	}
+
+// extensionField is a single tagged field of an extension struct.
+type extensionField struct {
+	slug      string
+	omitEmpty bool
+	value     reflect.Value
+}
+
+// extensionFields returns the tagged fields of an extension, which must be a struct or a pointer to a struct.
+func extensionFields(ext interface{}) ([]extensionField, error) {
+	value := reflect.ValueOf(ext)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, errors.New("error extension must not be nil")
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("error extension must be a struct, not %s", value.Kind())
+	}
+
+	var fields []extensionField
+	for i := 0; i < value.NumField(); i++ {
+		structField := value.Type().Field(i)
+
+		tag, ok := structField.Tag.Lookup("flow")
+		if !ok || tag == "-" || structField.PkgPath != "" {
+			continue
+		}
+
+		options := strings.Split(tag, ",")
+		field := extensionField{
+			slug:  options[0],
+			value: value.Field(i),
+		}
+		if field.slug == "" {
+			return nil, fmt.Errorf("error extension field %s has an empty flow tag", structField.Name)
+		}
+		for _, option := range options[1:] {
+			if option == "omitempty" {
+				field.omitEmpty = true
+			}
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// encodeExtension returns the flow field values of an extension keyed by field slug.
+func encodeExtension(ext interface{}) (map[string]json.RawMessage, error) {
+	fields, err := extensionFields(ext)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		if field.omitEmpty && field.value.IsZero() {
+			continue
+		}
+
+		value, err := json.Marshal(field.value.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("error encoding flow field %s: %v", field.slug, err)
+		}
+		attributes[field.slug] = value
+	}
+
+	return attributes, nil
+}
+
+// decodeExtension sets the fields of an extension from attributes keyed by field slug.
+// ext must be a pointer to a struct. Fields without a matching attribute are left unchanged.
+func decodeExtension(attributes map[string]json.RawMessage, ext interface{}) error {
+	if reflect.ValueOf(ext).Kind() != reflect.Ptr {
+		return errors.New("error extension must be a pointer to a struct")
+	}
+
+	fields, err := extensionFields(ext)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		raw, ok := attributes[field.slug]
+		if !ok || string(raw) == "null" {
+			continue
+		}
+
+		if err := json.Unmarshal(raw, field.value.Addr().Interface()); err != nil {
+			return fmt.Errorf("error decoding flow field %s: %v", field.slug, err)
+		}
+	}
+
+	return nil
+}
+
+// withExtension encodes a resource wrapped in a data object, adding the flow fields of an extension to it.
+func withExtension(resource interface{}, ext interface{}) ([]byte, error) {
+	resourceJSON, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(resourceJSON, &attributes); err != nil {
+		return nil, err
+	}
+
+	extension, err := encodeExtension(ext)
+	if err != nil {
+		return nil, err
+	}
+
+	for slug, value := range extension {
+		attributes[slug] = value
+	}
+
+	return json.Marshal(map[string]interface{}{"data": attributes})
+}
@@ -0,0 +1,73 @@
+package epcc
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testExtension struct {
+	Colour   string   `flow:"colour"`
+	Warranty int      `flow:"warranty_months,omitempty"`
+	Tags     []string `flow:"tags,omitempty"`
+	Ignored  string
+	internal string `flow:"internal"`
+}
+
+func TestEncodeExtension(t *testing.T) {
+	tests := []struct {
+		ext        interface{}
+		attributes map[string]json.RawMessage
+		err        error
+	}{
+		{
+			ext: testExtension{Colour: "green", Warranty: 12, Ignored: "ignored"},
+			attributes: map[string]json.RawMessage{
+				"colour":          json.RawMessage(`"green"`),
+				"warranty_months": json.RawMessage(`12`),
+			},
+		},
+		{
+			ext: &testExtension{},
+			attributes: map[string]json.RawMessage{
+				"colour": json.RawMessage(`""`),
+			},
+		},
+		{
+			ext: "not a struct",
+			err: errors.New("error extension must be a struct, not string"),
+		},
+		{
+			ext: (*testExtension)(nil),
+			err: errors.New("error extension must not be nil"),
+		},
+	}
+
+	for _, test := range tests {
+		attributes, err := encodeExtension(test.ext)
+		assert.Equal(t, test.attributes, attributes)
+		assert.Equal(t, test.err, err)
+	}
+}
+
+func TestDecodeExtension(t *testing.T) {
+	attributes := map[string]json.RawMessage{
+		"colour":          json.RawMessage(`"green"`),
+		"warranty_months": json.RawMessage(`null`),
+		"tags":            json.RawMessage(`["paper","frog"]`),
+		"internal":        json.RawMessage(`"secret"`),
+	}
+
+	ext := testExtension{Warranty: 6}
+	err := decodeExtension(attributes, &ext)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, testExtension{Colour: "green", Warranty: 6, Tags: []string{"paper", "frog"}}, ext)
+
+	err = decodeExtension(attributes, ext)
+	assert.Equal(t, errors.New("error extension must be a pointer to a struct"), err)
+
+	err = decodeExtension(map[string]json.RawMessage{"colour": json.RawMessage(`5`)}, &ext)
+	assert.Equal(t, "error decoding flow field colour: json: cannot unmarshal number into Go value of type string", err.Error())
+}
@@ -0,0 +1,109 @@
+package epcc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Fields is used to access the Fields endpoints.
+var Fields fields
+
+type fields struct{}
+
+// Get fetches a single field
+func (fields) Get(client *Client, fieldID string) (*FieldData, error) {
+	path := fmt.Sprintf("/v2/fields/%s", fieldID)
+
+	body, err := client.DoRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var field FieldData
+	if err := json.Unmarshal(body, &field); err != nil {
+		return nil, err
+	}
+
+	return &field, nil
+}
+
+// GetAll fetches all fields of a flow
+func (fields) GetAll(client *Client, flowSlug string) (*FieldsData, error) {
+	path := fmt.Sprintf("/v2/flows/%s/fields", flowSlug)
+
+	body, err := client.DoRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields FieldsData
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+
+	return &fields, nil
+}
+
+// Create creates a field
+func (fields) Create(client *Client, field *Field) (*FieldData, error) {
+	fieldData := FieldData{
+		Data: *field,
+	}
+
+	jsonPayload, err := json.Marshal(fieldData)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/v2/fields")
+
+	body, err := client.DoRequest("POST", path, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, err
+	}
+
+	var newField FieldData
+	if err := json.Unmarshal(body, &newField); err != nil {
+		return nil, err
+	}
+
+	return &newField, nil
+}
+
+// Update updates a field.
+func (fields) Update(client *Client, fieldID string, field *Field) (*FieldData, error) {
+	fieldData := FieldData{
+		Data: *field,
+	}
+
+	jsonPayload, err := json.Marshal(fieldData)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/v2/fields/%s", fieldID)
+
+	body, err := client.DoRequest("PUT", path, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedField FieldData
+	if err := json.Unmarshal(body, &updatedField); err != nil {
+		return nil, err
+	}
+
+	return &updatedField, nil
+}
+
+// Delete deletes a field.
+func (fields) Delete(client *Client, fieldID string) error {
+	path := fmt.Sprintf("/v2/fields/%s", fieldID)
+
+	if _, err := client.DoRequest("DELETE", path, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
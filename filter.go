@@ -0,0 +1,92 @@
+package epcc
+
+import (
+	"strings"
+	"time"
+)
+
+// Filter builds the filter query parameter used to filter lists on the server,
+// for example eq(sku,FRG):gt(updated_at,2020-09-01T00:00:00Z).
+// Each method returns a new Filter with the expression added, all expressions must match.
+type Filter struct {
+	expressions []string
+}
+
+// RawFilter returns a filter holding an expression already in the syntax of the filter query parameter.
+func RawFilter(expression string) Filter {
+	if expression == "" {
+		return Filter{}
+	}
+	return Filter{expressions: []string{expression}}
+}
+
+// Eq matches resources where the attribute equals value.
+func (f Filter) Eq(attribute string, value string) Filter {
+	return f.with("eq", attribute, value)
+}
+
+// Like matches resources where the attribute matches value, which may contain * wildcards.
+func (f Filter) Like(attribute string, value string) Filter {
+	return f.with("like", attribute, value)
+}
+
+// In matches resources where the attribute equals any of the values.
+func (f Filter) In(attribute string, values ...string) Filter {
+	return f.with("in", attribute, values...)
+}
+
+// Gt matches resources where the attribute is greater than value.
+func (f Filter) Gt(attribute string, value string) Filter {
+	return f.with("gt", attribute, value)
+}
+
+// Ge matches resources where the attribute is greater than or equal to value.
+func (f Filter) Ge(attribute string, value string) Filter {
+	return f.with("ge", attribute, value)
+}
+
+// Lt matches resources where the attribute is less than value.
+func (f Filter) Lt(attribute string, value string) Filter {
+	return f.with("lt", attribute, value)
+}
+
+// Le matches resources where the attribute is less than or equal to value.
+func (f Filter) Le(attribute string, value string) Filter {
+	return f.with("le", attribute, value)
+}
+
+// UpdatedSince matches resources updated after a time.
+func (f Filter) UpdatedSince(since time.Time) Filter {
+	return f.Gt("updated_at", since.UTC().Format(time.RFC3339))
+}
+
+// IsEmpty reports whether the filter has no expressions.
+func (f Filter) IsEmpty() bool {
+	return len(f.expressions) == 0
+}
+
+// String returns the filter in the syntax of the filter query parameter.
+func (f Filter) String() string {
+	return strings.Join(f.expressions, ":")
+}
+
+func (f Filter) with(operator string, attribute string, values ...string) Filter {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = quoteFilterValue(value)
+	}
+
+	expressions := make([]string, len(f.expressions), len(f.expressions)+1)
+	copy(expressions, f.expressions)
+	expressions = append(expressions, operator+"("+attribute+","+strings.Join(quoted, ",")+")")
+
+	return Filter{expressions: expressions}
+}
+
+// quoteFilterValue wraps values containing characters with a meaning in filters in double quotes.
+func quoteFilterValue(value string) string {
+	if strings.ContainsAny(value, ",() \"") {
+		return `"` + strings.Replace(value, `"`, `\"`, -1) + `"`
+	}
+	return value
+}
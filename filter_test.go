@@ -0,0 +1,38 @@
+package epcc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter(t *testing.T) {
+	since := time.Date(2020, 9, 1, 1, 0, 0, 0, time.FixedZone("BST", 3600))
+
+	tests := []struct {
+		filter   epcc.Filter
+		expected string
+	}{
+		{epcc.Filter{}, ""},
+		{epcc.Filter{}.Eq("sku", "FRG"), "eq(sku,FRG)"},
+		{epcc.Filter{}.Eq("sku", "FRG").Gt("stock", "0"), "eq(sku,FRG):gt(stock,0)"},
+		{epcc.Filter{}.In("sku", "FRG", "CAT", "TIGER"), "in(sku,FRG,CAT,TIGER)"},
+		{epcc.Filter{}.Like("name", "*Frog*"), "like(name,*Frog*)"},
+		{epcc.Filter{}.Eq("name", "Origami Frog"), `eq(name,"Origami Frog")`},
+		{epcc.Filter{}.Ge("price", "1").Le("price", "10").Lt("stock", "5"), "ge(price,1):le(price,10):lt(stock,5)"},
+		{epcc.Filter{}.UpdatedSince(since), "gt(updated_at,2020-09-01T00:00:00Z)"},
+		{epcc.RawFilter(""), ""},
+		{epcc.RawFilter("eq(status,live)").Gt("stock", "0"), "eq(status,live):gt(stock,0)"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.filter.String())
+	}
+
+	// Adding to a filter does not change the original.
+	base := epcc.Filter{}.Eq("status", "live")
+	_ = base.Eq("sku", "FRG")
+	assert.Equal(t, "eq(status,live)", base.String())
+}
@@ -0,0 +1,109 @@
+package epcc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Flows is used to access the Flows endpoints.
+var Flows flows
+
+type flows struct{}
+
+// Get fetches a single flow
+func (flows) Get(client *Client, flowID string) (*FlowData, error) {
+	path := fmt.Sprintf("/v2/flows/%s", flowID)
+
+	body, err := client.DoRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var flow FlowData
+	if err := json.Unmarshal(body, &flow); err != nil {
+		return nil, err
+	}
+
+	return &flow, nil
+}
+
+// GetAll fetches all flows
+func (flows) GetAll(client *Client) (*FlowsData, error) {
+	path := fmt.Sprintf("/v2/flows")
+
+	body, err := client.DoRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var flows FlowsData
+	if err := json.Unmarshal(body, &flows); err != nil {
+		return nil, err
+	}
+
+	return &flows, nil
+}
+
+// Create creates a flow
+func (flows) Create(client *Client, flow *Flow) (*FlowData, error) {
+	flowData := FlowData{
+		Data: *flow,
+	}
+
+	jsonPayload, err := json.Marshal(flowData)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/v2/flows")
+
+	body, err := client.DoRequest("POST", path, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, err
+	}
+
+	var newFlow FlowData
+	if err := json.Unmarshal(body, &newFlow); err != nil {
+		return nil, err
+	}
+
+	return &newFlow, nil
+}
+
+// Update updates a flow.
+func (flows) Update(client *Client, flowID string, flow *Flow) (*FlowData, error) {
+	flowData := FlowData{
+		Data: *flow,
+	}
+
+	jsonPayload, err := json.Marshal(flowData)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/v2/flows/%s", flowID)
+
+	body, err := client.DoRequest("PUT", path, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedFlow FlowData
+	if err := json.Unmarshal(body, &updatedFlow); err != nil {
+		return nil, err
+	}
+
+	return &updatedFlow, nil
+}
+
+// Delete deletes a flow.
+func (flows) Delete(client *Client, flowID string) error {
+	path := fmt.Sprintf("/v2/flows/%s", flowID)
+
+	if _, err := client.DoRequest("DELETE", path, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+package epcc_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func fakeHandleFlows(rw http.ResponseWriter, req *http.Request) {
+	var buffer bytes.Buffer
+	_, err := buffer.ReadFrom(req.Body)
+	if err != nil {
+		rw.WriteHeader(500)
+		return
+	}
+
+	switch {
+	case req.URL.String() == "/v2/flows" && req.Method == "POST" && strings.Contains(buffer.String(), `"slug":"products"`):
+		responseJSON := `{
+			"data":{
+				"id":"6d320b42-237d-4474-8452-d49f884d4ae1",
+				"type":"flow",
+				"name":"Products",
+				"slug":"products",
+				"description":"Extends the default product object",
+				"enabled":true,
+				"links":{
+					"self":"https://api.moltin.com/v2/flows/6d320b42-237d-4474-8452-d49f884d4ae1"
+				}
+			}
+		}`
+		rw.WriteHeader(201)
+		rw.Write([]byte(responseJSON))
+	case req.URL.String() == "/v2/fields" && req.Method == "POST" &&
+		strings.Contains(buffer.String(), `"slug":"colour"`) &&
+		strings.Contains(buffer.String(), `"flow":{"data":{"type":"flow","id":"6d320b42-237d-4474-8452-d49f884d4ae1"}}`):
+		responseJSON := `{
+			"data":{
+				"id":"0d3c2d1e-5c1d-4b52-9f0c-0a8bd8df1c43",
+				"type":"field",
+				"name":"Colour",
+				"slug":"colour",
+				"field_type":"string",
+				"required":false,
+				"unique":false,
+				"enabled":true,
+				"omit_null":false,
+				"validation_rules":[{
+					"type":"enum",
+					"options":["red","green"]
+				}],
+				"relationships":{
+					"flow":{
+						"data":{
+							"type":"flow",
+							"id":"6d320b42-237d-4474-8452-d49f884d4ae1"
+						}
+					}
+				}
+			}
+		}`
+		rw.WriteHeader(201)
+		rw.Write([]byte(responseJSON))
+	case req.URL.String() == "/v2/flows/wishlists/entries/validEntryID" && req.Method == "GET":
+		responseJSON := `{
+			"data":{
+				"id":"f1a3e0b7-3c15-4a8e-9d5e-92ec8e04a0b6",
+				"type":"entry",
+				"name":"Birthday",
+				"public":true,
+				"links":{
+					"self":"https://api.moltin.com/v2/flows/wishlists/entries/f1a3e0b7-3c15-4a8e-9d5e-92ec8e04a0b6"
+				}
+			}
+		}`
+		rw.WriteHeader(200)
+		rw.Write([]byte(responseJSON))
+	case req.URL.String() == "/v2/flows/wishlists/entries" && req.Method == "POST" && strings.Contains(buffer.String(), `"name":"Christmas"`):
+		responseJSON := `{
+			"data":{
+				"id":"9a0e3c77-8b54-4f7e-8f1d-3d3f6ad2b1d0",
+				"type":"entry",
+				"name":"Christmas",
+				"public":false
+			}
+		}`
+		rw.WriteHeader(201)
+		rw.Write([]byte(responseJSON))
+	default:
+		rw.WriteHeader(500)
+	}
+}
+
+func TestFlows(t *testing.T) {
+	// Create a new client and configure it to use test server instead of the real API endpoint.
+	testServer := httptest.NewServer(http.HandlerFunc(fakeHandleFlows))
+	options := epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	}
+	client := epcc.NewClient(options)
+
+	newFlow := epcc.Flow{
+		Type:        "flow",
+		Name:        "Products",
+		Slug:        "products",
+		Description: "Extends the default product object",
+		Enabled:     true,
+	}
+
+	expectedFlowData := epcc.FlowData{
+		Data: epcc.Flow{
+			ID:          "6d320b42-237d-4474-8452-d49f884d4ae1",
+			Type:        "flow",
+			Name:        "Products",
+			Slug:        "products",
+			Description: "Extends the default product object",
+			Enabled:     true,
+			Links: epcc.Links{
+				Self: "https://api.moltin.com/v2/flows/6d320b42-237d-4474-8452-d49f884d4ae1",
+			},
+		},
+	}
+
+	flowData, err := epcc.Flows.Create(client, &newFlow)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &expectedFlowData, flowData)
+
+	newField := epcc.Field{
+		Type:      "field",
+		Name:      "Colour",
+		Slug:      "colour",
+		FieldType: "string",
+		Enabled:   true,
+		ValidationRules: []epcc.FieldValidationRule{
+			{Type: "enum", Options: []string{"red", "green"}},
+		},
+		Relationships: epcc.FieldRelationships{
+			Flow: epcc.RelationshipItem{
+				Data: epcc.Relationship{Type: "flow", ID: flowData.Data.ID},
+			},
+		},
+	}
+
+	expectedFieldData := epcc.FieldData{
+		Data: epcc.Field{
+			ID:        "0d3c2d1e-5c1d-4b52-9f0c-0a8bd8df1c43",
+			Type:      "field",
+			Name:      "Colour",
+			Slug:      "colour",
+			FieldType: "string",
+			Enabled:   true,
+			ValidationRules: []epcc.FieldValidationRule{
+				{Type: "enum", Options: []interface{}{"red", "green"}},
+			},
+			Relationships: epcc.FieldRelationships{
+				Flow: epcc.RelationshipItem{
+					Data: epcc.Relationship{Type: "flow", ID: "6d320b42-237d-4474-8452-d49f884d4ae1"},
+				},
+			},
+		},
+	}
+
+	fieldData, err := epcc.Fields.Create(client, &newField)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &expectedFieldData, fieldData)
+}
+
+func TestEntries(t *testing.T) {
+	// Create a new client and configure it to use test server instead of the real API endpoint.
+	testServer := httptest.NewServer(http.HandlerFunc(fakeHandleFlows))
+	options := epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	}
+	client := epcc.NewClient(options)
+
+	expectedEntryData := epcc.EntryData{
+		Data: epcc.Entry{
+			ID:   "f1a3e0b7-3c15-4a8e-9d5e-92ec8e04a0b6",
+			Type: "entry",
+			Fields: map[string]interface{}{
+				"name":   "Birthday",
+				"public": true,
+			},
+		},
+	}
+
+	entryData, err := epcc.Entries.Get(client, "wishlists", "validEntryID")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &expectedEntryData, entryData)
+
+	newEntry := epcc.Entry{
+		Type: "entry",
+		Fields: map[string]interface{}{
+			"name":   "Christmas",
+			"public": false,
+		},
+	}
+
+	expectedNewEntryData := epcc.EntryData{
+		Data: epcc.Entry{
+			ID:   "9a0e3c77-8b54-4f7e-8f1d-3d3f6ad2b1d0",
+			Type: "entry",
+			Fields: map[string]interface{}{
+				"name":   "Christmas",
+				"public": false,
+			},
+		},
+	}
+
+	entryData, err = epcc.Entries.Create(client, "wishlists", &newEntry)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &expectedNewEntryData, entryData)
+}
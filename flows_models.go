@@ -0,0 +1,138 @@
+package epcc
+
+import "encoding/json"
+
+// FlowData contains the data for a single flow
+type FlowData struct {
+	Data Flow `json:"data"`
+}
+
+// FlowsData contains the data for multiple flows
+type FlowsData struct {
+	Data []Flow `json:"data"`
+}
+
+// Flow represents a flow, a collection of custom fields.
+// Flows with the slug of a core resource, such as "products", extend that resource.
+type Flow struct {
+	ID            string            `json:"id,omitempty"`
+	Type          string            `json:"type"`
+	Name          string            `json:"name,omitempty"`
+	Slug          string            `json:"slug,omitempty"`
+	Description   string            `json:"description,omitempty"`
+	Enabled       bool              `json:"enabled"`
+	Links         Links             `json:"links,omitempty"`
+	Relationships FlowRelationships `json:"relationships,omitempty"`
+	Meta          FlowMeta          `json:"meta,omitempty"`
+}
+
+// FlowRelationships represents the relationships that can exist for a flow
+type FlowRelationships struct {
+	Fields RelationshipItems `json:"fields,omitempty"`
+}
+
+// FlowMeta contains extra data for a flow, field or entry
+type FlowMeta struct {
+	Timestamps Timestamps `json:"timestamps,omitempty"`
+}
+
+// FieldData contains the data for a single field
+type FieldData struct {
+	Data Field `json:"data"`
+}
+
+// FieldsData contains the data for multiple fields
+type FieldsData struct {
+	Data []Field `json:"data"`
+}
+
+// Field represents a single custom field of a flow
+type Field struct {
+	ID              string                `json:"id,omitempty"`
+	Type            string                `json:"type"`
+	Name            string                `json:"name,omitempty"`
+	Slug            string                `json:"slug,omitempty"`
+	FieldType       string                `json:"field_type,omitempty"`
+	Description     string                `json:"description,omitempty"`
+	Required        bool                  `json:"required"`
+	Unique          bool                  `json:"unique"`
+	Default         interface{}           `json:"default,omitempty"`
+	Enabled         bool                  `json:"enabled"`
+	Order           int                   `json:"order,omitempty"`
+	OmitNull        bool                  `json:"omit_null"`
+	ValidationRules []FieldValidationRule `json:"validation_rules,omitempty"`
+	Links           Links                 `json:"links,omitempty"`
+	Relationships   FieldRelationships    `json:"relationships,omitempty"`
+	Meta            FlowMeta              `json:"meta,omitempty"`
+}
+
+// FieldValidationRule restricts the values a field accepts
+type FieldValidationRule struct {
+	Type    string      `json:"type"`
+	Options interface{} `json:"options,omitempty"`
+	To      string      `json:"to,omitempty"`
+}
+
+// FieldRelationships represents the relationships that can exist for a field
+type FieldRelationships struct {
+	Flow RelationshipItem `json:"flow,omitempty"`
+}
+
+// EntryData contains the data for a single entry
+type EntryData struct {
+	Data Entry `json:"data"`
+}
+
+// EntriesData contains the data for multiple entries
+type EntriesData struct {
+	Data []Entry `json:"data"`
+}
+
+// Entry is a single record of a flow.
+// Fields holds the value of each field keyed by field slug.
+type Entry struct {
+	ID     string
+	Type   string
+	Fields map[string]interface{}
+}
+
+// MarshalJSON encodes an entry with its fields alongside its id and type.
+func (e Entry) MarshalJSON() ([]byte, error) {
+	attributes := make(map[string]interface{}, len(e.Fields)+2)
+	for slug, value := range e.Fields {
+		attributes[slug] = value
+	}
+
+	if e.ID != "" {
+		attributes["id"] = e.ID
+	}
+	attributes["type"] = e.Type
+
+	return json.Marshal(attributes)
+}
+
+// UnmarshalJSON decodes an entry, collecting every attribute other than id, type, links and meta into Fields.
+func (e *Entry) UnmarshalJSON(data []byte) error {
+	var attributes map[string]interface{}
+	if err := json.Unmarshal(data, &attributes); err != nil {
+		return err
+	}
+
+	*e = Entry{}
+	if id, ok := attributes["id"].(string); ok {
+		e.ID = id
+	}
+	if entryType, ok := attributes["type"].(string); ok {
+		e.Type = entryType
+	}
+
+	for _, key := range []string{"id", "type", "links", "meta"} {
+		delete(attributes, key)
+	}
+
+	if len(attributes) > 0 {
+		e.Fields = attributes
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package epcc_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntriesDataUnmarshal(t *testing.T) {
+	rawJSON := `{
+		"data":[{
+			"id":"f1a3e0b7-3c15-4a8e-9d5e-92ec8e04a0b6",
+			"type":"entry",
+			"name":"Birthday",
+			"items":3,
+			"meta":{
+				"timestamps":{
+					"created_at":"2020-09-10T10:19:40.342Z",
+					"updated_at":"2020-09-10T10:19:40.342Z"
+				}
+			}
+		},{
+			"id":"9a0e3c77-8b54-4f7e-8f1d-3d3f6ad2b1d0",
+			"type":"entry"
+		}]
+	}`
+
+	expectedEntriesData := epcc.EntriesData{
+		Data: []epcc.Entry{
+			{
+				ID:   "f1a3e0b7-3c15-4a8e-9d5e-92ec8e04a0b6",
+				Type: "entry",
+				Fields: map[string]interface{}{
+					"name":  "Birthday",
+					"items": float64(3),
+				},
+			},
+			{
+				ID:   "9a0e3c77-8b54-4f7e-8f1d-3d3f6ad2b1d0",
+				Type: "entry",
+			},
+		},
+	}
+
+	var entriesData epcc.EntriesData
+	err := json.Unmarshal([]byte(rawJSON), &entriesData)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, expectedEntriesData, entriesData)
+}
+
+func TestEntryDataMarshal(t *testing.T) {
+	entryData := epcc.EntryData{
+		Data: epcc.Entry{
+			Type: "entry",
+			Fields: map[string]interface{}{
+				"name":   "Christmas",
+				"public": false,
+			},
+		},
+	}
+
+	rawJSON, err := json.Marshal(entryData)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{"data":{"name":"Christmas","public":false,"type":"entry"}}`, string(rawJSON))
+}
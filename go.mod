@@ -6,4 +6,5 @@ require (
 	github.com/kelseyhightower/envconfig v1.4.0
 	github.com/stretchr/testify v1.6.1
 	gopkg.in/retry.v1 v1.0.3
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,105 @@
+package epcc
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Names of the spans started by a client.
+const (
+	SpanRequest      = "epcc.request"      // SpanRequest covers a request, including every retry.
+	SpanAttempt      = "epcc.attempt"      // SpanAttempt is a child of SpanRequest for each attempt at sending the request.
+	SpanAuthenticate = "epcc.authenticate" // SpanAuthenticate covers fetching an access token.
+)
+
+// Keys of the span attributes set by a client, following the OpenTelemetry HTTP conventions.
+const (
+	AttributeMethod  = "http.request.method"
+	AttributeRoute   = "http.route"
+	AttributeStatus  = "http.response.status_code"
+	AttributeAttempt = "epcc.attempt"
+)
+
+// Attribute is a key and value describing a span.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Span is an operation being traced.
+type Span interface {
+	SetAttributes(attributes ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Tracer starts spans for a client. A span started with a context holding another span is its child,
+// and the context returned is passed to the client's middleware so it can be propagated.
+type Tracer interface {
+	Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, Span)
+}
+
+// AttemptMetrics describes one attempt at sending a request.
+type AttemptMetrics struct {
+	Method   string
+	Endpoint string        // Endpoint is the path with IDs replaced by {id}, such as /v2/products/{id}.
+	Status   int           // Status is the status code of the response, 0 when no response was received.
+	Attempt  int           // Attempt counts the attempts at sending the request from 1.
+	Retried  bool          // Retried is true when the request is sent again because of the status code.
+	Duration time.Duration // Duration is how long the attempt took.
+}
+
+// RequestMetrics describes a request once it has finished.
+type RequestMetrics struct {
+	Method   string
+	Endpoint string        // Endpoint is the path with IDs replaced by {id}, such as /v2/products/{id}.
+	Status   int           // Status is the status code of the last response, 0 when no response was received.
+	Attempts int           // Attempts is how many times the request was sent.
+	Duration time.Duration // Duration is how long the request took, including every retry.
+	Err      error         // Err is the error returned for the request.
+}
+
+// MetricsRecorder receives the metrics of a client's requests, including authentication.
+type MetricsRecorder interface {
+	RecordAttempt(metrics AttemptMetrics)
+	RecordRequest(metrics RequestMetrics)
+}
+
+type nopSpan struct{}
+
+func (nopSpan) SetAttributes(attributes ...Attribute) {}
+func (nopSpan) RecordError(err error)                 {}
+func (nopSpan) End()                                  {}
+
+// startSpan starts a span with the client's tracer, or a span which does nothing when it has none.
+func (c *Client) startSpan(ctx context.Context, name string, attributes ...Attribute) (context.Context, Span) {
+	if c.Tracer == nil {
+		return ctx, nopSpan{}
+	}
+	return c.Tracer.Start(ctx, name, attributes...)
+}
+
+func (c *Client) recordAttempt(metrics AttemptMetrics) {
+	if c.Metrics != nil {
+		c.Metrics.RecordAttempt(metrics)
+	}
+}
+
+func (c *Client) recordRequest(metrics RequestMetrics) {
+	if c.Metrics != nil {
+		c.Metrics.RecordRequest(metrics)
+	}
+}
+
+// endpoint returns a path with each segment which holds a digit, such as an ID, replaced by {id},
+// so metrics have one series for each endpoint rather than each resource.
+func endpoint(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if i > 1 && strings.ContainsAny(segment, "0123456789") {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,233 @@
+package epcc_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/Rosalita/go-epcc-client/epcctest"
+	"github.com/stretchr/testify/assert"
+)
+
+type spanKey struct{}
+
+// fakeSpan keeps what was recorded on a span.
+type fakeSpan struct {
+	name       string
+	parent     string
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (s *fakeSpan) SetAttributes(attributes ...epcc.Attribute) {
+	for _, attribute := range attributes {
+		s.attributes[attribute.Key] = attribute.Value
+	}
+}
+
+func (s *fakeSpan) RecordError(err error) { s.err = err }
+func (s *fakeSpan) End()                  { s.ended = true }
+
+// fakeTracer keeps every span it starts, with the name of its parent taken from the context.
+type fakeTracer struct {
+	mu    sync.Mutex
+	spans []*fakeSpan
+}
+
+func (t *fakeTracer) Start(ctx context.Context, name string, attributes ...epcc.Attribute) (context.Context, epcc.Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	span := &fakeSpan{name: name, attributes: map[string]interface{}{}}
+	if parent, ok := ctx.Value(spanKey{}).(*fakeSpan); ok {
+		span.parent = parent.name
+	}
+	span.SetAttributes(attributes...)
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// newInstrumentedClient returns a client of the test server with a tracer and metrics, it is not authenticated.
+func newInstrumentedClient(server *epcctest.Server, tracer epcc.Tracer, metrics epcc.MetricsRecorder) *epcc.Client {
+	options := server.ClientOptions()
+	options.Tracer = tracer
+	options.Metrics = metrics
+	return epcc.NewClient(options)
+}
+
+func TestTracerSpans(t *testing.T) {
+	server := epcctest.NewServer()
+	defer server.Close()
+	server.InjectFault(epcctest.Fault{Method: "GET", Path: "/v2/products", StatusCode: 503, Times: 1})
+
+	tracer := &fakeTracer{}
+	client := newInstrumentedClient(server, tracer, nil)
+	assert.Nil(t, client.Authenticate())
+
+	_, err := client.DoRequest("GET", "/v2/products", nil)
+	assert.Nil(t, err)
+
+	if assert.Equal(t, 4, len(tracer.spans)) {
+		auth, request, first, second := tracer.spans[0], tracer.spans[1], tracer.spans[2], tracer.spans[3]
+
+		assert.Equal(t, epcc.SpanAuthenticate, auth.name)
+		assert.Equal(t, "", auth.parent)
+		assert.Equal(t, 200, auth.attributes[epcc.AttributeStatus])
+
+		assert.Equal(t, epcc.SpanRequest, request.name)
+		assert.Equal(t, "", request.parent)
+		assert.Equal(t, "GET", request.attributes[epcc.AttributeMethod])
+		assert.Equal(t, "/v2/products", request.attributes[epcc.AttributeRoute])
+		assert.Equal(t, 200, request.attributes[epcc.AttributeStatus])
+
+		assert.Equal(t, epcc.SpanAttempt, first.name)
+		assert.Equal(t, epcc.SpanRequest, first.parent)
+		assert.Equal(t, 1, first.attributes[epcc.AttributeAttempt])
+		assert.Equal(t, 503, first.attributes[epcc.AttributeStatus])
+
+		assert.Equal(t, epcc.SpanAttempt, second.name)
+		assert.Equal(t, epcc.SpanRequest, second.parent)
+		assert.Equal(t, 2, second.attributes[epcc.AttributeAttempt])
+		assert.Equal(t, 200, second.attributes[epcc.AttributeStatus])
+
+		for _, span := range tracer.spans {
+			assert.True(t, span.ended, span.name)
+			assert.Nil(t, span.err, span.name)
+		}
+	}
+}
+
+func TestTracerRecordsErrors(t *testing.T) {
+	server := epcctest.NewServer()
+	defer server.Close()
+
+	tracer := &fakeTracer{}
+	client := newInstrumentedClient(server, tracer, nil)
+	assert.Nil(t, client.Authenticate())
+	tracer.spans = nil
+
+	_, err := client.DoRequestContext(context.Background(), "GET", "/v2/products/0a1b", nil)
+	assert.NotNil(t, err)
+
+	failed := errors.New("connection refused")
+	client.Use(epcc.BeforeSend(func(req *http.Request) error { return failed }))
+	_, err = client.DoRequest("GET", "/v2/products", nil)
+	assert.Equal(t, failed, err)
+
+	if assert.Equal(t, 4, len(tracer.spans)) {
+		assert.Equal(t, "/v2/products/{id}", tracer.spans[0].attributes[epcc.AttributeRoute])
+		assert.Equal(t, 404, tracer.spans[0].attributes[epcc.AttributeStatus])
+		assert.NotNil(t, tracer.spans[0].err)
+		assert.Nil(t, tracer.spans[1].err)
+
+		assert.Equal(t, failed, tracer.spans[2].err)
+		assert.Equal(t, failed, tracer.spans[3].err)
+		assert.Nil(t, tracer.spans[2].attributes[epcc.AttributeStatus])
+	}
+}
+
+func TestTracerContextReachesMiddleware(t *testing.T) {
+	server := epcctest.NewServer()
+	defer server.Close()
+
+	tracer := &fakeTracer{}
+	client := newInstrumentedClient(server, tracer, nil)
+	assert.Nil(t, client.Authenticate())
+
+	var span string
+	client.Use(epcc.BeforeSend(func(req *http.Request) error {
+		span = req.Context().Value(spanKey{}).(*fakeSpan).name
+		return nil
+	}))
+
+	_, err := client.DoRequest("GET", "/v2/products", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, epcc.SpanAttempt, span)
+}
+
+func TestPrometheusMetricsFromClient(t *testing.T) {
+	server := epcctest.NewServer()
+	defer server.Close()
+	server.InjectFault(epcctest.Fault{Method: "GET", Path: "/v2/products", StatusCode: 503, Times: 1})
+	frog := server.AddProduct(epcc.Product{Name: "Origami Frog", Slug: "frog", SKU: "FRG"})
+	crane := server.AddProduct(epcc.Product{Name: "Origami Crane", Slug: "crane", SKU: "CRN"})
+
+	metrics := epcc.NewPrometheusMetrics()
+	client := newInstrumentedClient(server, nil, metrics)
+	assert.Nil(t, client.Authenticate())
+
+	_, err := client.DoRequest("GET", "/v2/products", nil)
+	assert.Nil(t, err)
+	_, err = client.DoRequest("GET", "/v2/products/"+frog.ID, nil)
+	assert.Nil(t, err)
+	_, err = client.DoRequest("GET", "/v2/products/"+crane.ID, nil)
+	assert.Nil(t, err)
+
+	metricsServer := httptest.NewServer(metrics)
+	defer metricsServer.Close()
+
+	resp, err := http.Get(metricsServer.URL)
+	if assert.Nil(t, err) {
+		defer resp.Body.Close()
+		assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", resp.Header.Get("Content-Type"))
+
+		body, _ := ioutil.ReadAll(resp.Body)
+		for _, line := range []string{
+			`epcc_requests_total{endpoint="/oauth/access_token",method="POST",status="200"} 1`,
+			`epcc_requests_total{endpoint="/v2/products/{id}",method="GET",status="200"} 2`,
+			`epcc_requests_total{endpoint="/v2/products",method="GET",status="200"} 1`,
+			`epcc_request_attempts_total{endpoint="/v2/products",method="GET",status="200"} 1`,
+			`epcc_request_attempts_total{endpoint="/v2/products",method="GET",status="503"} 1`,
+			`epcc_request_retries_total{endpoint="/v2/products",method="GET"} 1`,
+			`epcc_request_duration_seconds_bucket{endpoint="/v2/products/{id}",method="GET",le="+Inf"} 2`,
+			`epcc_request_duration_seconds_count{endpoint="/v2/products/{id}",method="GET"} 2`,
+		} {
+			assert.Contains(t, string(body), line+"\n")
+		}
+		assert.NotContains(t, string(body), frog.ID)
+	}
+}
+
+func TestPrometheusMetricsWrite(t *testing.T) {
+	metrics := epcc.NewPrometheusMetrics()
+	metrics.RecordAttempt(epcc.AttemptMetrics{Method: "GET", Endpoint: `/v2/"quoted"`, Attempt: 1, Duration: time.Second})
+	metrics.RecordRequest(epcc.RequestMetrics{Method: "GET", Endpoint: `/v2/"quoted"`, Attempts: 1, Duration: 30 * time.Millisecond, Err: errors.New("failed")})
+
+	var out strings.Builder
+	assert.Nil(t, metrics.Write(&out))
+
+	expected := `# HELP epcc_requests_total Requests made by the client, by the status of their last response.
+# TYPE epcc_requests_total counter
+epcc_requests_total{endpoint="/v2/\"quoted\"",method="GET",status="error"} 1
+# HELP epcc_request_attempts_total Attempts at sending requests, including retries.
+# TYPE epcc_request_attempts_total counter
+epcc_request_attempts_total{endpoint="/v2/\"quoted\"",method="GET",status="error"} 1
+# HELP epcc_request_retries_total Attempts which were retried because of their status.
+# TYPE epcc_request_retries_total counter
+# HELP epcc_request_duration_seconds Duration of requests, including every retry.
+# TYPE epcc_request_duration_seconds histogram
+epcc_request_duration_seconds_bucket{endpoint="/v2/\"quoted\"",method="GET",le="0.005"} 0
+epcc_request_duration_seconds_bucket{endpoint="/v2/\"quoted\"",method="GET",le="0.01"} 0
+epcc_request_duration_seconds_bucket{endpoint="/v2/\"quoted\"",method="GET",le="0.025"} 0
+epcc_request_duration_seconds_bucket{endpoint="/v2/\"quoted\"",method="GET",le="0.05"} 1
+epcc_request_duration_seconds_bucket{endpoint="/v2/\"quoted\"",method="GET",le="0.1"} 1
+epcc_request_duration_seconds_bucket{endpoint="/v2/\"quoted\"",method="GET",le="0.25"} 1
+epcc_request_duration_seconds_bucket{endpoint="/v2/\"quoted\"",method="GET",le="0.5"} 1
+epcc_request_duration_seconds_bucket{endpoint="/v2/\"quoted\"",method="GET",le="1"} 1
+epcc_request_duration_seconds_bucket{endpoint="/v2/\"quoted\"",method="GET",le="2.5"} 1
+epcc_request_duration_seconds_bucket{endpoint="/v2/\"quoted\"",method="GET",le="5"} 1
+epcc_request_duration_seconds_bucket{endpoint="/v2/\"quoted\"",method="GET",le="10"} 1
+epcc_request_duration_seconds_bucket{endpoint="/v2/\"quoted\"",method="GET",le="+Inf"} 1
+epcc_request_duration_seconds_sum{endpoint="/v2/\"quoted\"",method="GET"} 0.03
+epcc_request_duration_seconds_count{endpoint="/v2/\"quoted\"",method="GET"} 1
+`
+	assert.Equal(t, expected, out.String())
+}
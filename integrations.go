@@ -0,0 +1,155 @@
+package epcc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Integrations is used to access the Integrations endpoints.
+var Integrations integrations
+
+type integrations struct{}
+
+// Get fetches a single integration
+func (integrations) Get(client *Client, integrationID string) (*IntegrationData, error) {
+	path := fmt.Sprintf("/v2/integrations/%s", integrationID)
+
+	body, err := client.DoRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var integration IntegrationData
+	if err := json.Unmarshal(body, &integration); err != nil {
+		return nil, err
+	}
+
+	return &integration, nil
+}
+
+// GetAll fetches all integrations
+func (integrations) GetAll(client *Client) (*IntegrationsData, error) {
+	path := fmt.Sprintf("/v2/integrations")
+
+	body, err := client.DoRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var integrations IntegrationsData
+	if err := json.Unmarshal(body, &integrations); err != nil {
+		return nil, err
+	}
+
+	return &integrations, nil
+}
+
+// Create creates an integration
+func (integrations) Create(client *Client, integration *Integration) (*IntegrationData, error) {
+	integrationData := IntegrationData{
+		Data: *integration,
+	}
+
+	jsonPayload, err := json.Marshal(integrationData)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/v2/integrations")
+
+	body, err := client.DoRequest("POST", path, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, err
+	}
+
+	var newIntegration IntegrationData
+	if err := json.Unmarshal(body, &newIntegration); err != nil {
+		return nil, err
+	}
+
+	return &newIntegration, nil
+}
+
+// Update updates an integration.
+func (integrations) Update(client *Client, integrationID string, integration *Integration) (*IntegrationData, error) {
+	integrationData := IntegrationData{
+		Data: *integration,
+	}
+
+	jsonPayload, err := json.Marshal(integrationData)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/v2/integrations/%s", integrationID)
+
+	body, err := client.DoRequest("PUT", path, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedIntegration IntegrationData
+	if err := json.Unmarshal(body, &updatedIntegration); err != nil {
+		return nil, err
+	}
+
+	return &updatedIntegration, nil
+}
+
+// Delete deletes an integration.
+func (integrations) Delete(client *Client, integrationID string) error {
+	path := fmt.Sprintf("/v2/integrations/%s", integrationID)
+
+	if _, err := client.DoRequest("DELETE", path, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetAllLogs fetches the delivery logs of every integration in the store
+func (integrations) GetAllLogs(client *Client) (*IntegrationLogsData, error) {
+	return getIntegrationLogs(client, "/v2/integrations/logs")
+}
+
+// GetLogs fetches the delivery logs of an integration
+func (integrations) GetLogs(client *Client, integrationID string) (*IntegrationLogsData, error) {
+	return getIntegrationLogs(client, fmt.Sprintf("/v2/integrations/%s/logs", integrationID))
+}
+
+// GetJobs fetches the jobs of an integration
+func (integrations) GetJobs(client *Client, integrationID string) (*IntegrationJobsData, error) {
+	path := fmt.Sprintf("/v2/integrations/%s/jobs", integrationID)
+
+	body, err := client.DoRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs IntegrationJobsData
+	if err := json.Unmarshal(body, &jobs); err != nil {
+		return nil, err
+	}
+
+	return &jobs, nil
+}
+
+// GetJobLogs fetches the delivery attempts of a single integration job
+func (integrations) GetJobLogs(client *Client, integrationID string, jobID string) (*IntegrationLogsData, error) {
+	return getIntegrationLogs(client, fmt.Sprintf("/v2/integrations/%s/jobs/%s/logs", integrationID, jobID))
+}
+
+func getIntegrationLogs(client *Client, path string) (*IntegrationLogsData, error) {
+	body, err := client.DoRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var logs IntegrationLogsData
+	if err := json.Unmarshal(body, &logs); err != nil {
+		return nil, err
+	}
+
+	return &logs, nil
+}
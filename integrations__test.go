@@ -0,0 +1,172 @@
+package epcc_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func fakeHandleIntegrations(rw http.ResponseWriter, req *http.Request) {
+	var buffer bytes.Buffer
+	_, err := buffer.ReadFrom(req.Body)
+	if err != nil {
+		rw.WriteHeader(500)
+		return
+	}
+
+	switch {
+	case req.URL.String() == "/v2/integrations" && req.Method == "POST" &&
+		strings.Contains(buffer.String(), `"observes":["order.created","product.updated"]`) &&
+		strings.Contains(buffer.String(), `"configuration":{"url":"https://example.com/webhooks","secret_key":"secret"}`):
+		responseJSON := `{
+			"data":{
+				"id":"8c3f0dbe-06e1-4d2e-9d7b-2a4b5b3b3e91",
+				"type":"integration",
+				"name":"Order notifications",
+				"enabled":true,
+				"integration_type":"webhook",
+				"observes":["order.created","product.updated"],
+				"configuration":{
+					"url":"https://example.com/webhooks",
+					"secret_key":"secret"
+				}
+			}
+		}`
+		rw.WriteHeader(201)
+		rw.Write([]byte(responseJSON))
+	case req.URL.String() == "/v2/integrations/validIntegrationID/logs" && req.Method == "GET":
+		responseJSON := `{
+			"data":[{
+				"id":"1d2a0d44-6a8e-4a0b-8c27-3b6c4aab1e60",
+				"type":"integration-log",
+				"succeeded":false,
+				"attempt":2,
+				"body":"Internal Server Error",
+				"status_code":500,
+				"error_detail":"Non 2XX status code received",
+				"relationships":{
+					"integration":{
+						"data":{"type":"integration","id":"8c3f0dbe-06e1-4d2e-9d7b-2a4b5b3b3e91"}
+					},
+					"job":{
+						"data":{"type":"integration-job","id":"5b9c2a4e-93c4-4a0f-bb5a-0b2c2f7c8d11"}
+					}
+				}
+			}]
+		}`
+		rw.WriteHeader(200)
+		rw.Write([]byte(responseJSON))
+	case req.URL.String() == "/v2/integrations/validIntegrationID/jobs" && req.Method == "GET":
+		responseJSON := `{
+			"data":[{
+				"id":"5b9c2a4e-93c4-4a0f-bb5a-0b2c2f7c8d11",
+				"type":"integration-job"
+			}]
+		}`
+		rw.WriteHeader(200)
+		rw.Write([]byte(responseJSON))
+	case req.URL.String() == "/v2/integrations/validIntegrationID" && req.Method == "DELETE":
+		rw.WriteHeader(204)
+	case req.URL.String() == "/v2/integrations/notFound" && req.Method == "DELETE":
+		rw.WriteHeader(404)
+	default:
+		rw.WriteHeader(500)
+	}
+}
+
+func TestIntegrations(t *testing.T) {
+	// Create a new client and configure it to use test server instead of the real API endpoint.
+	testServer := httptest.NewServer(http.HandlerFunc(fakeHandleIntegrations))
+	options := epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	}
+	client := epcc.NewClient(options)
+
+	newIntegration := epcc.Integration{
+		Type:            "integration",
+		Name:            "Order notifications",
+		Enabled:         true,
+		IntegrationType: epcc.IntegrationTypeWebhook,
+		Observes:        []epcc.IntegrationEvent{epcc.EventOrderCreated, epcc.EventProductUpdated},
+		Configuration: epcc.IntegrationConfiguration{
+			URL:       "https://example.com/webhooks",
+			SecretKey: "secret",
+		},
+	}
+
+	expectedIntegrationData := epcc.IntegrationData{
+		Data: epcc.Integration{
+			ID:              "8c3f0dbe-06e1-4d2e-9d7b-2a4b5b3b3e91",
+			Type:            "integration",
+			Name:            "Order notifications",
+			Enabled:         true,
+			IntegrationType: epcc.IntegrationTypeWebhook,
+			Observes:        []epcc.IntegrationEvent{epcc.EventOrderCreated, epcc.EventProductUpdated},
+			Configuration: epcc.IntegrationConfiguration{
+				URL:       "https://example.com/webhooks",
+				SecretKey: "secret",
+			},
+		},
+	}
+
+	integrationData, err := epcc.Integrations.Create(client, &newIntegration)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &expectedIntegrationData, integrationData)
+
+	expectedLogs := epcc.IntegrationLogsData{
+		Data: []epcc.IntegrationLog{
+			{
+				ID:          "1d2a0d44-6a8e-4a0b-8c27-3b6c4aab1e60",
+				Type:        "integration-log",
+				Succeeded:   false,
+				Attempt:     2,
+				Body:        "Internal Server Error",
+				StatusCode:  500,
+				ErrorDetail: "Non 2XX status code received",
+				Relationships: epcc.IntegrationLogRelationships{
+					Integration: epcc.RelationshipItem{
+						Data: epcc.Relationship{Type: "integration", ID: "8c3f0dbe-06e1-4d2e-9d7b-2a4b5b3b3e91"},
+					},
+					Job: epcc.RelationshipItem{
+						Data: epcc.Relationship{Type: "integration-job", ID: "5b9c2a4e-93c4-4a0f-bb5a-0b2c2f7c8d11"},
+					},
+				},
+			},
+		},
+	}
+
+	logs, err := epcc.Integrations.GetLogs(client, "validIntegrationID")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &expectedLogs, logs)
+
+	expectedJobs := epcc.IntegrationJobsData{
+		Data: []epcc.IntegrationJob{
+			{ID: "5b9c2a4e-93c4-4a0f-bb5a-0b2c2f7c8d11", Type: "integration-job"},
+		},
+	}
+
+	jobs, err := epcc.Integrations.GetJobs(client, "validIntegrationID")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &expectedJobs, jobs)
+
+	tests := []struct {
+		integrationID string
+		err           error
+	}{
+		{"validIntegrationID", nil},
+		{"notFound", &epcc.APIError{StatusCode: 404}},
+	}
+
+	for _, test := range tests {
+		err := epcc.Integrations.Delete(client, test.integrationID)
+		assert.Equal(t, test.err, err)
+	}
+}
@@ -0,0 +1,113 @@
+package epcc
+
+// Integration types supported by the integrations endpoints.
+const (
+	IntegrationTypeWebhook = "webhook"
+	IntegrationTypeAWSSQS  = "aws_sqs"
+)
+
+// IntegrationEvent is an event an integration can observe.
+type IntegrationEvent string
+
+// Events which can be observed by an integration.
+const (
+	EventOrderCreated            IntegrationEvent = "order.created"
+	EventOrderUpdated            IntegrationEvent = "order.updated"
+	EventOrderDeleted            IntegrationEvent = "order.deleted"
+	EventOrderPaid               IntegrationEvent = "order.paid"
+	EventOrderAuthorized         IntegrationEvent = "order.authorized"
+	EventOrderShipped            IntegrationEvent = "order.shipped"
+	EventOrderFulfilled          IntegrationEvent = "order.fulfilled"
+	EventOrderRefunded           IntegrationEvent = "order.refunded"
+	EventProductCreated          IntegrationEvent = "product.created"
+	EventProductUpdated          IntegrationEvent = "product.updated"
+	EventProductDeleted          IntegrationEvent = "product.deleted"
+	EventCurrencyCreated         IntegrationEvent = "currency.created"
+	EventCurrencyUpdated         IntegrationEvent = "currency.updated"
+	EventCurrencyDeleted         IntegrationEvent = "currency.deleted"
+	EventCustomerCreated         IntegrationEvent = "customer.created"
+	EventCustomerUpdated         IntegrationEvent = "customer.updated"
+	EventCustomerDeleted         IntegrationEvent = "customer.deleted"
+	EventAccountCreated          IntegrationEvent = "account.created"
+	EventAccountUpdated          IntegrationEvent = "account.updated"
+	EventAccountDeleted          IntegrationEvent = "account.deleted"
+	EventPromotionCreated        IntegrationEvent = "promotion.created"
+	EventPromotionUpdated        IntegrationEvent = "promotion.updated"
+	EventPromotionDeleted        IntegrationEvent = "promotion.deleted"
+	EventStockTransactionCreated IntegrationEvent = "stock-transaction.created"
+)
+
+// IntegrationData contains the data for a single integration
+type IntegrationData struct {
+	Data Integration `json:"data"`
+}
+
+// IntegrationsData contains the data for multiple integrations
+type IntegrationsData struct {
+	Data []Integration `json:"data"`
+}
+
+// Integration sends events to a webhook or queue
+type Integration struct {
+	ID              string                   `json:"id,omitempty"`
+	Type            string                   `json:"type"`
+	Name            string                   `json:"name,omitempty"`
+	Description     string                   `json:"description,omitempty"`
+	Enabled         bool                     `json:"enabled"`
+	IntegrationType string                   `json:"integration_type,omitempty"`
+	Observes        []IntegrationEvent       `json:"observes,omitempty"`
+	Configuration   IntegrationConfiguration `json:"configuration,omitempty"`
+	Links           Links                    `json:"links,omitempty"`
+	Meta            IntegrationMeta          `json:"meta,omitempty"`
+}
+
+// IntegrationConfiguration describes where an integration delivers events.
+// Webhooks use URL and SecretKey, SQS integrations use URL and the AWS fields.
+type IntegrationConfiguration struct {
+	URL                string `json:"url,omitempty"`
+	SecretKey          string `json:"secret_key,omitempty"`
+	AWSAccessKeyID     string `json:"aws_access_key_id,omitempty"`
+	AWSSecretAccessKey string `json:"aws_secret_access_key,omitempty"`
+	Region             string `json:"region,omitempty"`
+}
+
+// IntegrationMeta contains extra data for an integration
+type IntegrationMeta struct {
+	Timestamps Timestamps `json:"timestamps,omitempty"`
+}
+
+// IntegrationLogsData contains the delivery logs of integrations
+type IntegrationLogsData struct {
+	Data []IntegrationLog `json:"data"`
+}
+
+// IntegrationLog records a single attempt to deliver an event
+type IntegrationLog struct {
+	ID            string                      `json:"id"`
+	Type          string                      `json:"type"`
+	Succeeded     bool                        `json:"succeeded"`
+	Attempt       int                         `json:"attempt"`
+	Body          string                      `json:"body"`
+	StatusCode    int                         `json:"status_code"`
+	ErrorDetail   string                      `json:"error_detail"`
+	Relationships IntegrationLogRelationships `json:"relationships,omitempty"`
+	Meta          IntegrationMeta             `json:"meta,omitempty"`
+}
+
+// IntegrationLogRelationships represents the relationships that can exist for an integration log
+type IntegrationLogRelationships struct {
+	Integration RelationshipItem `json:"integration,omitempty"`
+	Job         RelationshipItem `json:"job,omitempty"`
+}
+
+// IntegrationJobsData contains the jobs of an integration
+type IntegrationJobsData struct {
+	Data []IntegrationJob `json:"data"`
+}
+
+// IntegrationJob is a single event to be delivered by an integration, which may take several attempts
+type IntegrationJob struct {
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Meta IntegrationMeta `json:"meta,omitempty"`
+}
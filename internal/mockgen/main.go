@@ -0,0 +1,237 @@
+// Command mockgen writes mocks of the interfaces declared in a file of the epcc package.
+//
+// Each mock is a struct with a function field for each method, named after the method with a Func suffix.
+// Calling a method records the call and calls the function, or panics when the function is not set.
+//
+//	go run ./internal/mockgen -source services.go -out epccmock/mocks.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const epccImport = "github.com/Rosalita/go-epcc-client"
+
+func main() {
+	source := flag.String("source", "", "file declaring the interfaces")
+	out := flag.String("out", "", "file to write the mocks to")
+	flag.Parse()
+
+	if *source == "" || *out == "" {
+		fmt.Fprintln(os.Stderr, "usage: mockgen -source file.go -out mocks/file.go")
+		os.Exit(2)
+	}
+
+	src, err := ioutil.ReadFile(*source)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "mockgen: %s\n", err)
+		os.Exit(1)
+	}
+
+	pkg := filepath.Base(filepath.Dir(*out))
+	generated, err := generate(filepath.Base(*source), src, pkg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "mockgen: %s\n", err)
+		os.Exit(1)
+	}
+
+	if err := ioutil.WriteFile(*out, generated, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "mockgen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// generate returns the source of a package holding a mock of each interface declared in src.
+func generate(name string, src []byte, pkg string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, name, src, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	g := generator{imports: map[string]string{}, used: map[string]bool{epccImport: true}}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		g.imports[path[strings.LastIndex(path, "/")+1:]] = path
+	}
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			iface, ok := typeSpec.Type.(*ast.InterfaceType)
+			if !ok || !typeSpec.Name.IsExported() {
+				continue
+			}
+			if err := g.mock(typeSpec.Name.Name, iface); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var header bytes.Buffer
+	fmt.Fprintf(&header, "// Code generated by internal/mockgen from %s. DO NOT EDIT.\n\n", name)
+	fmt.Fprintf(&header, "package %s\n\nimport (\n", pkg)
+	var paths []string
+	for path := range g.used {
+		if path != epccImport {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fmt.Fprintf(&header, "\t%q\n", path)
+	}
+	fmt.Fprintf(&header, "\n\t%q\n)\n", epccImport)
+
+	return format.Source(append(header.Bytes(), g.body.Bytes()...))
+}
+
+type generator struct {
+	imports map[string]string // imports maps the package names used in the source to their paths.
+	used    map[string]bool
+	body    bytes.Buffer
+}
+
+type method struct {
+	name    string
+	params  []string
+	types   []string
+	results []string
+}
+
+// mock writes the mock of an interface.
+func (g *generator) mock(name string, iface *ast.InterfaceType) error {
+	var methods []method
+	for _, field := range iface.Methods.List {
+		funcType, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) != 1 {
+			return fmt.Errorf("%s embeds an interface, which is not supported", name)
+		}
+
+		m := method{name: field.Names[0].Name}
+		for _, param := range funcType.Params.List {
+			typ, err := g.typeString(param.Type)
+			if err != nil {
+				return err
+			}
+			for _, paramName := range param.Names {
+				m.params = append(m.params, paramName.Name)
+				m.types = append(m.types, typ)
+			}
+			if len(param.Names) == 0 {
+				m.params = append(m.params, fmt.Sprintf("p%d", len(m.params)))
+				m.types = append(m.types, typ)
+			}
+		}
+		if funcType.Results != nil {
+			for _, result := range funcType.Results.List {
+				typ, err := g.typeString(result.Type)
+				if err != nil {
+					return err
+				}
+				m.results = append(m.results, typ)
+			}
+		}
+		methods = append(methods, m)
+	}
+
+	fmt.Fprintf(&g.body, "\n// %s is a mock of epcc.%s.\n", name, name)
+	fmt.Fprintf(&g.body, "type %s struct {\n\trecorder\n\n", name)
+	for _, m := range methods {
+		fmt.Fprintf(&g.body, "\t%sFunc func%s\n", m.name, m.signature())
+	}
+	g.body.WriteString("}\n")
+
+	for _, m := range methods {
+		fmt.Fprintf(&g.body, "\n// %s records the call and calls %sFunc.\n", m.name, m.name)
+		fmt.Fprintf(&g.body, "func (m *%s) %s%s {\n", name, m.name, m.signature())
+		fmt.Fprintf(&g.body, "\tm.record(%q", m.name)
+		for _, param := range m.params {
+			fmt.Fprintf(&g.body, ", %s", param)
+		}
+		g.body.WriteString(")\n")
+		fmt.Fprintf(&g.body, "\tif m.%sFunc == nil {\n", m.name)
+		fmt.Fprintf(&g.body, "\t\tpanic(\"epccmock: %s.%s called but %sFunc is not set\")\n\t}\n", name, m.name, m.name)
+		if len(m.results) > 0 {
+			g.body.WriteString("\treturn ")
+		} else {
+			g.body.WriteString("\t")
+		}
+		fmt.Fprintf(&g.body, "m.%sFunc(%s)\n}\n", m.name, strings.Join(m.params, ", "))
+	}
+	return nil
+}
+
+// signature returns the parameters and results of a method.
+func (m method) signature() string {
+	params := make([]string, len(m.params))
+	for i := range m.params {
+		params[i] = m.params[i] + " " + m.types[i]
+	}
+
+	signature := "(" + strings.Join(params, ", ") + ")"
+	switch len(m.results) {
+	case 0:
+	case 1:
+		signature += " " + m.results[0]
+	default:
+		signature += " (" + strings.Join(m.results, ", ") + ")"
+	}
+	return signature
+}
+
+// typeString writes a type as it is named outside the epcc package.
+func (g *generator) typeString(expr ast.Expr) (string, error) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if t.IsExported() {
+			return "epcc." + t.Name, nil
+		}
+		return t.Name, nil
+	case *ast.StarExpr:
+		elem, err := g.typeString(t.X)
+		return "*" + elem, err
+	case *ast.ArrayType:
+		if t.Len != nil {
+			return "", fmt.Errorf("arrays are not supported")
+		}
+		elem, err := g.typeString(t.Elt)
+		return "[]" + elem, err
+	case *ast.MapType:
+		key, err := g.typeString(t.Key)
+		if err != nil {
+			return "", err
+		}
+		value, err := g.typeString(t.Value)
+		return "map[" + key + "]" + value, err
+	case *ast.SelectorExpr:
+		pkg := t.X.(*ast.Ident).Name
+		path, ok := g.imports[pkg]
+		if !ok {
+			return "", fmt.Errorf("package %s is not imported", pkg)
+		}
+		g.used[path] = true
+		return pkg + "." + t.Sel.Name, nil
+	case *ast.InterfaceType:
+		if len(t.Methods.List) == 0 {
+			return "interface{}", nil
+		}
+	}
+	return "", fmt.Errorf("type %T is not supported", expr)
+}
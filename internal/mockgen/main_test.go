@@ -0,0 +1,39 @@
+package main
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMocksAreGenerated(t *testing.T) {
+	src, err := ioutil.ReadFile("../../services.go")
+	assert.Equal(t, nil, err)
+	mocks, err := ioutil.ReadFile("../../epccmock/mocks.go")
+	assert.Equal(t, nil, err)
+
+	generated, err := generate("services.go", src, "epccmock")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, string(mocks), string(generated), "epccmock is out of date, run go generate in the epcc package")
+}
+
+func TestGenerateUnsupported(t *testing.T) {
+	src := []byte(`package epcc
+
+type Embedding interface {
+	RequestExecutor
+}
+`)
+	_, err := generate("embed.go", src, "epccmock")
+	assert.EqualError(t, err, "Embedding embeds an interface, which is not supported")
+
+	src = []byte(`package epcc
+
+type Callback interface {
+	Do(f func()) error
+}
+`)
+	_, err = generate("callback.go", src, "epccmock")
+	assert.EqualError(t, err, "type *ast.FuncType is not supported")
+}
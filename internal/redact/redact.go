@@ -0,0 +1,37 @@
+// Package redact finds credentials in the names and values logged by epcc and recorded by epcctest.
+package redact
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces credentials.
+const Redacted = "REDACTED"
+
+// sensitiveKey matches the names of fields, JSON attributes and form values which hold credentials.
+var sensitiveKey = regexp.MustCompile(`(?i)token|secret|password|authorization`)
+
+// Patterns of credentials in text, such as error bodies.
+var (
+	sensitiveJSON   = regexp.MustCompile(`(?i)(\\?"[a-z_-]*(?:token|secret|password)\\?"\s*:\s*\\?")[^"\\]*`)
+	sensitiveForm   = regexp.MustCompile(`(?i)\b([a-z_-]*(?:token|secret|password)=)[^&\s]*`)
+	sensitiveBearer = regexp.MustCompile(`(?i)(bearer )[^\s"]+`)
+)
+
+// Key reports whether the name of a field, JSON attribute or form value is one whose value is redacted.
+func Key(name string) bool {
+	return sensitiveKey.MatchString(name)
+}
+
+// Text replaces the secrets wherever they appear in text, and the values of credentials in JSON, forms and bearer tokens.
+func Text(text string, secrets ...string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			text = strings.ReplaceAll(text, secret, Redacted)
+		}
+	}
+	text = sensitiveJSON.ReplaceAllString(text, `${1}`+Redacted)
+	text = sensitiveForm.ReplaceAllString(text, `${1}`+Redacted)
+	return sensitiveBearer.ReplaceAllString(text, `${1}`+Redacted)
+}
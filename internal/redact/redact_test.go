@@ -0,0 +1,32 @@
+package redact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKey(t *testing.T) {
+	for _, key := range []string{"access_token", "client_secret", "Password", "Authorization"} {
+		assert.Equal(t, true, Key(key), key)
+	}
+	for _, key := range []string{"path", "status", "client_id"} {
+		assert.Equal(t, false, Key(key), key)
+	}
+}
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{`{"access_token":"abc","token_type":"Bearer"}`, `{"access_token":"REDACTED","token_type":"Bearer"}`},
+		{"client_id=id&client_secret=shh", "client_id=id&client_secret=REDACTED"},
+		{"Authorization: Bearer abc.def", "Authorization: Bearer REDACTED"},
+		{"the token knownToken expired", "the token REDACTED expired"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, Text(test.text, "knownToken", ""))
+	}
+}
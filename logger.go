@@ -0,0 +1,142 @@
+package epcc
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Rosalita/go-epcc-client/internal/redact"
+)
+
+// Level is the severity of a log entry.
+type Level int
+
+// Log levels, from the most to the least detailed.
+const (
+	LevelDebug Level = iota // LevelDebug logs every request.
+	LevelInfo               // LevelInfo logs authentication.
+	LevelWarn               // LevelWarn logs retried requests and responses which are not ok.
+	LevelError              // LevelError logs requests which could not be made.
+)
+
+// String returns the name of the level.
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	}
+	return fmt.Sprintf("level(%d)", int(l))
+}
+
+// LogField is a key and value describing a log entry, such as the method, path, status, attempt or duration of a request.
+type LogField struct {
+	Key   string
+	Value interface{}
+}
+
+// Logger receives the log entries of a client. Tokens and secrets are redacted before they reach it.
+type Logger interface {
+	Log(level Level, msg string, fields ...LogField)
+}
+
+// LoggerFunc is a function which implements Logger.
+type LoggerFunc func(level Level, msg string, fields ...LogField)
+
+// Log calls f.
+func (f LoggerFunc) Log(level Level, msg string, fields ...LogField) {
+	f(level, msg, fields...)
+}
+
+// NopLogger discards every log entry, it is used when a client has no logger.
+var NopLogger nopLogger
+
+type nopLogger struct{}
+
+// Log discards the entry.
+func (nopLogger) Log(level Level, msg string, fields ...LogField) {}
+
+// NewStdLogger returns a logger which writes entries at or above a level to a standard logger as key=value pairs,
+// such as level=warn msg="retrying request" method=GET path=/v2/products status=503 attempt=1.
+// Entries are written to stderr when logger is nil.
+func NewStdLogger(logger *log.Logger, level Level) Logger {
+	if logger == nil {
+		logger = log.New(os.Stderr, "", log.LstdFlags)
+	}
+	return stdLogger{print: logger.Print, level: level}
+}
+
+type stdLogger struct {
+	print func(v ...interface{})
+	level Level
+}
+
+func (l stdLogger) Log(level Level, msg string, fields ...LogField) {
+	if level < l.level {
+		return
+	}
+
+	var line strings.Builder
+	line.WriteString("level=" + level.String() + " msg=" + formatValue(msg))
+	for _, field := range fields {
+		line.WriteString(" " + field.Key + "=" + formatValue(field.Value))
+	}
+	l.print(line.String())
+}
+
+// formatValue writes a value, quoting text which has spaces, quotes or equals signs.
+func formatValue(value interface{}) string {
+	text := fmt.Sprint(value)
+	if text == "" || strings.ContainsAny(text, " \t\n\"=") {
+		return strconv.Quote(text)
+	}
+	return text
+}
+
+// Redacted replaces tokens and secrets in log entries.
+const Redacted = redact.Redacted
+
+// redactFields replaces fields whose keys name credentials, and credentials found in text values.
+// Secrets, such as the client's own tokens, are also replaced wherever they appear.
+func redactFields(fields []LogField, secrets ...string) []LogField {
+	redacted := make([]LogField, len(fields))
+	for i, field := range fields {
+		redacted[i] = field
+		if redact.Key(field.Key) {
+			redacted[i].Value = Redacted
+			continue
+		}
+
+		var text string
+		switch value := field.Value.(type) {
+		case string:
+			text = value
+		case error:
+			text = value.Error()
+		default:
+			continue
+		}
+		redacted[i].Value = redact.Text(text, secrets...)
+	}
+	return redacted
+}
+
+// log sends an entry to the client's logger with its credentials redacted.
+func (c *Client) log(level Level, msg string, fields ...LogField) {
+	if c.Logger == nil {
+		return
+	}
+
+	c.mu.RLock()
+	secrets := []string{c.clientSecret, c.accessToken, c.accountToken}
+	c.mu.RUnlock()
+
+	c.Logger.Log(level, redact.Text(msg, secrets...), redactFields(fields, secrets...)...)
+}
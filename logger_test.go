@@ -0,0 +1,135 @@
+package epcc_test
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/stretchr/testify/assert"
+)
+
+type logEntry struct {
+	level  epcc.Level
+	msg    string
+	fields map[string]interface{}
+}
+
+// entryLogger keeps every entry logged.
+type entryLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *entryLogger) Log(level epcc.Level, msg string, fields ...epcc.LogField) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entry := logEntry{level: level, msg: msg, fields: map[string]interface{}{}}
+	for _, field := range fields {
+		entry.fields[field.Key] = field.Value
+	}
+	l.entries = append(l.entries, entry)
+}
+
+func fakeHandleLogging(rw http.ResponseWriter, req *http.Request) {
+	switch req.URL.Path {
+	case "/oauth/access_token":
+		rw.WriteHeader(200)
+		rw.Write([]byte(`{"access_token":"secretAccessToken","token_type":"Bearer"}`))
+	case "/v2/products":
+		rw.WriteHeader(200)
+		rw.Write([]byte(`{"data":[]}`))
+	case "/v2/busy":
+		rw.WriteHeader(503)
+	default:
+		rw.WriteHeader(401)
+		rw.Write([]byte(`{"errors":[{"status":401,"title":"Unauthorized","detail":"token secretAccessToken expired, send client_secret=clientSecret or {\"password\":\"hunter2\"}"}]}`))
+	}
+}
+
+func TestClientLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(fakeHandleLogging))
+	defer server.Close()
+
+	logger := &entryLogger{}
+	client := epcc.NewClient(epcc.ClientOptions{
+		BaseURL:           server.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 50 * time.Millisecond,
+		ClientID:          "clientID",
+		ClientSecret:      "clientSecret",
+		Logger:            logger,
+	})
+
+	assert.Equal(t, nil, client.Authenticate())
+	_, err := client.DoRequest("GET", "/v2/products", nil)
+	assert.Equal(t, nil, err)
+	_, err = client.DoRequest("GET", "/v2/busy", nil)
+	assert.Equal(t, errors.New("retry timeout error"), err)
+	_, err = client.DoRequest("GET", "/v2/expired", nil)
+	assert.NotEqual(t, nil, err)
+
+	entries := logger.entries
+	assert.Equal(t, epcc.LevelInfo, entries[0].level)
+	assert.Equal(t, "authentication successful", entries[0].msg)
+	assert.Equal(t, "/oauth/access_token", entries[0].fields["path"])
+
+	assert.Equal(t, epcc.LevelDebug, entries[1].level)
+	assert.Equal(t, "request succeeded", entries[1].msg)
+	assert.Equal(t, "GET", entries[1].fields["method"])
+	assert.Equal(t, "/v2/products", entries[1].fields["path"])
+	assert.Equal(t, 200, entries[1].fields["status"])
+	assert.Equal(t, 1, entries[1].fields["attempt"])
+	assert.IsType(t, time.Duration(0), entries[1].fields["duration"])
+
+	assert.Equal(t, epcc.LevelWarn, entries[2].level)
+	assert.Equal(t, "retrying request", entries[2].msg)
+	assert.Equal(t, 503, entries[2].fields["status"])
+	assert.Equal(t, 2, entries[3].fields["attempt"])
+
+	timeout := entries[len(entries)-4]
+	assert.Equal(t, epcc.LevelError, timeout.level)
+	assert.Equal(t, "retry timeout", timeout.msg)
+
+	// The rejected token is replaced once before the request fails.
+	reauthenticate := entries[len(entries)-3]
+	assert.Equal(t, epcc.LevelInfo, reauthenticate.level)
+	assert.Equal(t, "authenticating again", reauthenticate.msg)
+	assert.Equal(t, "unauthorized", reauthenticate.fields["reason"])
+	assert.Equal(t, "authentication successful", entries[len(entries)-2].msg)
+
+	notOK := entries[len(entries)-1]
+	assert.Equal(t, epcc.LevelWarn, notOK.level)
+	assert.Equal(t, "response is not ok", notOK.msg)
+	assert.Equal(t, 401, notOK.fields["status"])
+	body := notOK.fields["body"].(string)
+	assert.Equal(t, false, strings.Contains(body, "secretAccessToken"))
+	assert.Equal(t, false, strings.Contains(body, "clientSecret"))
+	assert.Equal(t, false, strings.Contains(body, "hunter2"))
+	assert.Contains(t, body, "token REDACTED expired")
+}
+
+func TestStdLogger(t *testing.T) {
+	var buffer bytes.Buffer
+	logger := epcc.NewStdLogger(log.New(&buffer, "", 0), epcc.LevelInfo)
+
+	logger.Log(epcc.LevelDebug, "request succeeded", epcc.LogField{Key: "path", Value: "/v2/products"})
+	logger.Log(epcc.LevelWarn, "retrying request",
+		epcc.LogField{Key: "method", Value: "GET"},
+		epcc.LogField{Key: "path", Value: "/v2/products"},
+		epcc.LogField{Key: "status", Value: 503},
+		epcc.LogField{Key: "duration", Value: 1500 * time.Millisecond},
+		epcc.LogField{Key: "body", Value: `{"errors":[]}`},
+	)
+	assert.Equal(t, `level=warn msg="retrying request" method=GET path=/v2/products status=503 duration=1.5s body="{\"errors\":[]}"`+"\n", buffer.String())
+
+	epcc.NopLogger.Log(epcc.LevelError, "discarded")
+	assert.Equal(t, "error", epcc.LevelError.String())
+	assert.Equal(t, "level(7)", epcc.Level(7).String())
+}
@@ -0,0 +1,177 @@
+package epcc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"time"
+)
+
+// DefaultRequestIDHeader is the header RequestIDMiddleware sets when none is given.
+const DefaultRequestIDHeader = "X-Request-ID"
+
+// Doer sends a request and returns its response. *http.Client implements it.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is a function which implements Doer.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f.
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps how a client sends requests, to change requests before they are sent
+// or responses after they are received. Every request passes through it, including
+// authentication and each retry.
+type Middleware func(next Doer) Doer
+
+// Use adds middleware to the client. The first middleware added sees each request first
+// and each response last. Middleware should be added before the client is used.
+func (c *Client) Use(middleware ...Middleware) {
+	c.Middleware = append(c.Middleware, middleware...)
+}
+
+// do sends a request through the client's middleware.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	var doer Doer = c.HTTPClient
+	for i := len(c.Middleware) - 1; i >= 0; i-- {
+		doer = c.Middleware[i](doer)
+	}
+	return doer.Do(req)
+}
+
+// BeforeSend returns middleware which calls fn with each request before it is sent.
+// The request is not sent when fn returns an error.
+func BeforeSend(fn func(req *http.Request) error) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if err := fn(req); err != nil {
+				return nil, err
+			}
+			return next.Do(req)
+		})
+	}
+}
+
+// AfterReceive returns middleware which calls fn with each response after it is received.
+// The response body is closed and the error returned when fn returns an error.
+func AfterReceive(fn func(resp *http.Response) error) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.Do(req)
+			if err != nil {
+				return nil, err
+			}
+			if err := fn(resp); err != nil {
+				resp.Body.Close()
+				return nil, err
+			}
+			return resp, nil
+		})
+	}
+}
+
+// HeaderMiddleware returns middleware which sets headers on every request.
+func HeaderMiddleware(header http.Header) Middleware {
+	return BeforeSend(func(req *http.Request) error {
+		for name, values := range header {
+			req.Header.Del(name)
+			for _, value := range values {
+				req.Header.Add(name, value)
+			}
+		}
+		return nil
+	})
+}
+
+// LoggingMiddleware returns middleware which logs the method, path, status and duration of every request
+// to a standard logger as key=value pairs, like LogMiddleware with NewStdLogger at LevelInfo.
+// The standard logger is used when logger is nil.
+func LoggingMiddleware(logger *log.Logger) Middleware {
+	if logger == nil {
+		return LogMiddleware(stdLogger{print: log.Print, level: LevelInfo})
+	}
+	return LogMiddleware(NewStdLogger(logger, LevelInfo))
+}
+
+// LogMiddleware returns middleware which logs the method, path, status and duration of every request
+// at LevelInfo, and requests which could not be made at LevelError. Entries are written to stderr by
+// NewStdLogger when logger is nil. Headers and bodies are not logged, as they hold credentials.
+//
+// Unlike the client's own log entries, the middleware does not know the client's secret and tokens,
+// so only credentials found by their names and patterns, such as a bearer token in an error, are redacted.
+func LogMiddleware(logger Logger) Middleware {
+	if logger == nil {
+		logger = NewStdLogger(nil, LevelInfo)
+	}
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req)
+			fields := []LogField{{"method", req.Method}, {"path", req.URL.Path}}
+			duration := LogField{"duration", time.Since(start).Round(time.Millisecond)}
+			if err != nil {
+				logger.Log(LevelError, "request failed", redactFields(append(fields, duration, LogField{"error", err}))...)
+				return nil, err
+			}
+			logger.Log(LevelInfo, "request", redactFields(append(fields, LogField{"status", resp.StatusCode}, duration))...)
+			return resp, nil
+		})
+	}
+}
+
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a context carrying a request ID, which RequestIDMiddleware
+// sends with requests made by DoRequestContext.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID carried by a context, or an empty string.
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// newRequestID returns a random request ID.
+func newRequestID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// RequestIDMiddleware returns middleware which sets a request ID header on every request,
+// X-Request-ID when header is empty. The ID is taken from the request's context so it can be
+// propagated from an incoming request. DoRequestContext generates one ID for a request without one,
+// so every retry of a request is sent with the same ID, and authentication gets a random ID.
+// A request ID already set on the request is kept.
+func RequestIDMiddleware(header string) Middleware {
+	if header == "" {
+		header = DefaultRequestIDHeader
+	}
+
+	return BeforeSend(func(req *http.Request) error {
+		if req.Header.Get(header) != "" {
+			return nil
+		}
+
+		requestID := RequestIDFromContext(req.Context())
+		if requestID == "" {
+			var err error
+			if requestID, err = newRequestID(); err != nil {
+				return err
+			}
+		}
+		req.Header.Set(header, requestID)
+		return nil
+	})
+}
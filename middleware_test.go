@@ -0,0 +1,191 @@
+package epcc_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/Rosalita/go-epcc-client/epcctest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newMiddlewareClient returns a client of the test server which sends requests through middleware.
+func newMiddlewareClient(server *epcctest.Server, middleware ...epcc.Middleware) *epcc.Client {
+	options := server.ClientOptions()
+	options.Middleware = middleware
+	return epcc.NewClient(options)
+}
+
+// requestHeaders returns the headers of every request the server received for a path.
+func requestHeaders(server *epcctest.Server, path string) []http.Header {
+	var headers []http.Header
+	for _, request := range server.Requests() {
+		if request.Path == path {
+			headers = append(headers, request.Header)
+		}
+	}
+	return headers
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	server := epcctest.NewServer()
+	defer server.Close()
+
+	var trace []string
+	tracer := func(name string) epcc.Middleware {
+		return func(next epcc.Doer) epcc.Doer {
+			return epcc.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				trace = append(trace, name+" before")
+				resp, err := next.Do(req)
+				trace = append(trace, name+" after")
+				return resp, err
+			})
+		}
+	}
+
+	client := newMiddlewareClient(server, tracer("first"))
+	client.Use(tracer("second"))
+	assert.Equal(t, nil, client.Authenticate())
+	trace = nil
+
+	_, err := client.DoRequest("GET", "/v2/products", nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"first before", "second before", "second after", "first after"}, trace)
+}
+
+func TestHeaderMiddleware(t *testing.T) {
+	server := epcctest.NewServer()
+	defer server.Close()
+	server.InjectFault(epcctest.Fault{Method: "GET", Path: "/v2/products", StatusCode: 503, Times: 1})
+
+	client := newMiddlewareClient(server, epcc.HeaderMiddleware(http.Header{"X-Store": {"staging"}}))
+	assert.Equal(t, nil, client.Authenticate())
+	_, err := client.DoRequest("GET", "/v2/products", nil)
+	assert.Equal(t, nil, err)
+
+	// Authentication and every attempt of a retried request pass through the middleware.
+	assert.Equal(t, "staging", requestHeaders(server, "/oauth/access_token")[0].Get("X-Store"))
+	headers := requestHeaders(server, "/v2/products")
+	assert.Equal(t, 2, len(headers))
+	for _, header := range headers {
+		assert.Equal(t, "staging", header.Get("X-Store"))
+		assert.Equal(t, "Bearer "+client.AccessToken(), header.Get("Authorization"))
+	}
+}
+
+func TestBeforeSendAndAfterReceive(t *testing.T) {
+	server := epcctest.NewServer()
+	defer server.Close()
+	server.InjectFault(epcctest.Fault{Method: "GET", Path: "/v2/products", StatusCode: 503, Times: 1})
+
+	client := newMiddlewareClient(server, epcc.BeforeSend(func(req *http.Request) error {
+		if req.Method == "DELETE" {
+			return errors.New("error deleting is not allowed")
+		}
+		return nil
+	}))
+	_, err := client.DoRequest("DELETE", "/v2/products/productID", nil)
+	assert.Equal(t, errors.New("error deleting is not allowed"), err)
+
+	var statuses []int
+	client = newMiddlewareClient(server, epcc.AfterReceive(func(resp *http.Response) error {
+		statuses = append(statuses, resp.StatusCode)
+		if resp.Request.URL.Path == "/v2/forbidden" {
+			return errors.New("error forbidden path")
+		}
+		return nil
+	}))
+	assert.Equal(t, nil, client.Authenticate())
+	_, err = client.DoRequest("GET", "/v2/products", nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []int{200, 503, 200}, statuses)
+
+	_, err = client.DoRequest("GET", "/v2/forbidden", nil)
+	assert.Equal(t, errors.New("error forbidden path"), err)
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	server := epcctest.NewServer()
+	defer server.Close()
+
+	logger := &entryLogger{}
+	client := newMiddlewareClient(server, epcc.LogMiddleware(logger))
+	assert.Equal(t, nil, client.Authenticate())
+	_, err := client.DoRequest("GET", "/v2/products?filter=eq(sku,CRN)", nil)
+	assert.Equal(t, nil, err)
+
+	if assert.Equal(t, 2, len(logger.entries)) {
+		for i, path := range []string{"/oauth/access_token", "/v2/products"} {
+			entry := logger.entries[i]
+			assert.Equal(t, epcc.LevelInfo, entry.level)
+			assert.Equal(t, "request", entry.msg)
+			assert.Equal(t, path, entry.fields["path"])
+			assert.Equal(t, 200, entry.fields["status"])
+			assert.IsType(t, time.Duration(0), entry.fields["duration"])
+		}
+		assert.Equal(t, "POST", logger.entries[0].fields["method"])
+		assert.Equal(t, "GET", logger.entries[1].fields["method"])
+	}
+
+	logger = &entryLogger{}
+	options := server.ClientOptions()
+	options.BaseURL = "http://127.0.0.1:1"
+	options.Middleware = []epcc.Middleware{epcc.LogMiddleware(logger)}
+	_, err = epcc.NewClient(options).DoRequest("GET", "/v2/products", nil)
+	assert.NotEqual(t, nil, err)
+	if assert.Equal(t, 1, len(logger.entries)) {
+		assert.Equal(t, epcc.LevelError, logger.entries[0].level)
+		assert.Equal(t, "request failed", logger.entries[0].msg)
+		assert.NotEqual(t, nil, logger.entries[0].fields["error"])
+	}
+
+	// A standard logger writes the entries as key=value pairs.
+	var buffer bytes.Buffer
+	client = newMiddlewareClient(server, epcc.LoggingMiddleware(log.New(&buffer, "", 0)))
+	assert.Equal(t, nil, client.Authenticate())
+	assert.Regexp(t, `^level=info msg=request method=POST path=/oauth/access_token status=200 duration=\d+m?s\n$`, buffer.String())
+	assert.Equal(t, false, strings.Contains(buffer.String(), epcctest.ClientSecret))
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	server := epcctest.NewServer()
+	defer server.Close()
+	server.InjectFault(epcctest.Fault{Method: "GET", Path: "/v2/currencies", StatusCode: 503, Times: 1})
+
+	client := newMiddlewareClient(server, epcc.RequestIDMiddleware(""))
+	assert.Equal(t, nil, client.Authenticate())
+
+	ctx := epcc.ContextWithRequestID(context.Background(), "incoming-request-id")
+	assert.Equal(t, "incoming-request-id", epcc.RequestIDFromContext(ctx))
+	_, err := client.DoRequestContext(ctx, "GET", "/v2/products", nil)
+	assert.Equal(t, nil, err)
+	_, err = client.DoRequest("GET", "/v2/currencies", nil)
+	assert.Equal(t, nil, err)
+
+	authentication := requestHeaders(server, "/oauth/access_token")[0].Get("X-Request-ID")
+	assert.Regexp(t, `^[0-9a-f]{32}$`, authentication)
+	assert.Equal(t, "incoming-request-id", requestHeaders(server, "/v2/products")[0].Get("X-Request-ID"))
+
+	// A retried request is sent with the same generated ID.
+	retried := requestHeaders(server, "/v2/currencies")
+	assert.Equal(t, 2, len(retried))
+	assert.Regexp(t, `^[0-9a-f]{32}$`, retried[0].Get("X-Request-ID"))
+	assert.Equal(t, retried[0].Get("X-Request-ID"), retried[1].Get("X-Request-ID"))
+	assert.NotEqual(t, authentication, retried[0].Get("X-Request-ID"))
+
+	// An ID already set on the request is kept.
+	client = newMiddlewareClient(server,
+		epcc.HeaderMiddleware(http.Header{"Correlation-Id": {"set-by-header"}}),
+		epcc.RequestIDMiddleware("Correlation-ID"),
+	)
+	_, err = client.DoRequestContext(ctx, "GET", "/v2/flows", nil)
+	assert.NotEqual(t, nil, err)
+	requests := server.Requests()
+	assert.Equal(t, "set-by-header", requests[len(requests)-1].Header.Get("Correlation-ID"))
+}
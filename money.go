@@ -0,0 +1,248 @@
+package epcc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ErrCurrencyMismatch is returned when money in different currencies is combined.
+var ErrCurrencyMismatch = errors.New("error currencies do not match")
+
+// ErrOverflow is returned when the result of arithmetic on money does not fit in an int64.
+var ErrOverflow = errors.New("error amount overflows")
+
+// Money is an amount in the minor unit of a currency, such as pence or cents.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// NewMoney creates Money from an amount in minor units and a currency code.
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: strings.ToUpper(currency)}
+}
+
+// Money returns the amount and currency of a product price.
+func (p ProductPrice) Money() Money {
+	return NewMoney(int64(p.Amount), p.Currency)
+}
+
+// ProductPrice converts money to a product price.
+func (m Money) ProductPrice(includesTax bool) ProductPrice {
+	return ProductPrice{
+		Amount:      int(m.Amount),
+		Currency:    m.Currency,
+		IncludesTax: includesTax,
+	}
+}
+
+// Add returns the sum of two amounts of money in the same currency.
+func (m Money) Add(other Money) (Money, error) {
+	if !m.sameCurrency(other) {
+		return Money{}, ErrCurrencyMismatch
+	}
+	if (other.Amount > 0 && m.Amount > math.MaxInt64-other.Amount) ||
+		(other.Amount < 0 && m.Amount < math.MinInt64-other.Amount) {
+		return Money{}, ErrOverflow
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+}
+
+// Sub returns the difference of two amounts of money in the same currency.
+func (m Money) Sub(other Money) (Money, error) {
+	if other.Amount == math.MinInt64 {
+		return Money{}, ErrOverflow
+	}
+	return m.Add(Money{Amount: -other.Amount, Currency: other.Currency})
+}
+
+// Multiply returns the money multiplied by a quantity.
+func (m Money) Multiply(quantity int64) (Money, error) {
+	if m.Amount != 0 && quantity != 0 {
+		product := m.Amount * quantity
+		if product/quantity != m.Amount || (m.Amount == math.MinInt64 && quantity == -1) {
+			return Money{}, ErrOverflow
+		}
+	}
+	return Money{Amount: m.Amount * quantity, Currency: m.Currency}, nil
+}
+
+// IsZero reports whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// String returns the amount and currency code, such as "1050 GBP".
+func (m Money) String() string {
+	return fmt.Sprintf("%d %s", m.Amount, m.Currency)
+}
+
+func (m Money) sameCurrency(other Money) bool {
+	return strings.EqualFold(m.Currency, other.Currency)
+}
+
+// Converter converts money between the currencies of a store.
+//
+// Exchange rates are relative to a common base, normally the default currency with a rate of 1,
+// so converting from A to B multiplies by B's rate and divides by A's. Amounts are scaled
+// between the decimal places of each currency and rounded half away from zero.
+// Rates are used as the shortest decimal which represents their float64, so 1.13 is exactly 1.13.
+type Converter struct {
+	currencies map[string]Currency
+	order      []string
+}
+
+// NewConverter creates a Converter for a set of currencies, such as the result of Currencies.GetAll.
+func NewConverter(currencies []Currency) *Converter {
+	converter := Converter{
+		currencies: make(map[string]Currency, len(currencies)),
+	}
+
+	for _, currency := range currencies {
+		code := strings.ToUpper(currency.Code)
+		if _, ok := converter.currencies[code]; !ok {
+			converter.order = append(converter.order, code)
+		}
+		converter.currencies[code] = currency
+	}
+
+	return &converter
+}
+
+// Convert converts money into another currency.
+func (c *Converter) Convert(m Money, to string) (Money, error) {
+	from, err := c.currency(m.Currency)
+	if err != nil {
+		return Money{}, err
+	}
+	target, err := c.currency(to)
+	if err != nil {
+		return Money{}, err
+	}
+
+	if strings.EqualFold(from.Code, target.Code) {
+		return NewMoney(m.Amount, target.Code), nil
+	}
+
+	fromRate, err := exchangeRate(from)
+	if err != nil {
+		return Money{}, err
+	}
+	toRate, err := exchangeRate(target)
+	if err != nil {
+		return Money{}, err
+	}
+
+	// amount * toRate / fromRate * 10^(target places - source places)
+	value := new(big.Rat).SetInt64(m.Amount)
+	value.Mul(value, toRate)
+	value.Quo(value, fromRate)
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(absInt64(target.DecimalPlaces-from.DecimalPlaces)), nil)
+	if target.DecimalPlaces > from.DecimalPlaces {
+		value.Mul(value, new(big.Rat).SetInt(scale))
+	} else {
+		value.Quo(value, new(big.Rat).SetInt(scale))
+	}
+
+	amount := roundHalfAwayFromZero(value)
+	if !amount.IsInt64() {
+		return Money{}, ErrOverflow
+	}
+
+	return NewMoney(amount.Int64(), target.Code), nil
+}
+
+// FillPrices adds a price to a product for every enabled currency which it does not have a price in.
+// Missing prices are converted from the product's price in the default currency.
+func (c *Converter) FillPrices(product *Product) error {
+	var defaultCurrency *Currency
+	for _, code := range c.order {
+		currency := c.currencies[code]
+		if currency.Default {
+			defaultCurrency = &currency
+			break
+		}
+	}
+	if defaultCurrency == nil {
+		return errors.New("error no default currency")
+	}
+
+	var defaultPrice *ProductPrice
+	priced := make(map[string]bool, len(product.Price))
+	for i, price := range product.Price {
+		priced[strings.ToUpper(price.Currency)] = true
+		if strings.EqualFold(price.Currency, defaultCurrency.Code) {
+			defaultPrice = &product.Price[i]
+		}
+	}
+	if defaultPrice == nil {
+		return fmt.Errorf("error product has no price in default currency %s", defaultCurrency.Code)
+	}
+
+	base := defaultPrice.Money()
+	includesTax := defaultPrice.IncludesTax
+
+	for _, code := range c.order {
+		currency := c.currencies[code]
+		if !currency.Enabled || priced[code] {
+			continue
+		}
+
+		converted, err := c.Convert(base, code)
+		if err != nil {
+			return err
+		}
+		product.Price = append(product.Price, converted.ProductPrice(includesTax))
+	}
+
+	return nil
+}
+
+func (c *Converter) currency(code string) (Currency, error) {
+	currency, ok := c.currencies[strings.ToUpper(code)]
+	if !ok {
+		return Currency{}, fmt.Errorf("error unknown currency %s", code)
+	}
+	return currency, nil
+}
+
+// exchangeRate returns the exchange rate of a currency as an exact decimal.
+func exchangeRate(currency Currency) (*big.Rat, error) {
+	if currency.ExchangeRate <= 0 || math.IsInf(currency.ExchangeRate, 0) || math.IsNaN(currency.ExchangeRate) {
+		return nil, fmt.Errorf("error currency %s has invalid exchange rate %v", currency.Code, currency.ExchangeRate)
+	}
+
+	rate, ok := new(big.Rat).SetString(strconv.FormatFloat(currency.ExchangeRate, 'f', -1, 64))
+	if !ok {
+		return nil, fmt.Errorf("error currency %s has invalid exchange rate %v", currency.Code, currency.ExchangeRate)
+	}
+	return rate, nil
+}
+
+// roundHalfAwayFromZero rounds a rational number to the nearest integer, rounding halves away from zero.
+func roundHalfAwayFromZero(value *big.Rat) *big.Int {
+	numerator := new(big.Int).Abs(value.Num())
+	denominator := value.Denom()
+
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if remainder.Mul(remainder, big.NewInt(2)).Cmp(denominator) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+
+	if value.Sign() < 0 {
+		quotient.Neg(quotient)
+	}
+	return quotient
+}
+
+func absInt64(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,113 @@
+package epcc_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoneyArithmetic(t *testing.T) {
+	tenPounds := epcc.NewMoney(1000, "gbp")
+	assert.Equal(t, epcc.Money{Amount: 1000, Currency: "GBP"}, tenPounds)
+
+	sum, err := tenPounds.Add(epcc.NewMoney(50, "GBP"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, epcc.NewMoney(1050, "GBP"), sum)
+
+	difference, err := tenPounds.Sub(epcc.NewMoney(1050, "GBP"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, epcc.NewMoney(-50, "GBP"), difference)
+
+	total, err := tenPounds.Multiply(3)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, epcc.NewMoney(3000, "GBP"), total)
+
+	_, err = tenPounds.Add(epcc.NewMoney(1000, "USD"))
+	assert.Equal(t, epcc.ErrCurrencyMismatch, err)
+
+	_, err = epcc.NewMoney(math.MaxInt64, "GBP").Add(epcc.NewMoney(1, "GBP"))
+	assert.Equal(t, epcc.ErrOverflow, err)
+
+	_, err = epcc.NewMoney(math.MinInt64, "GBP").Multiply(-1)
+	assert.Equal(t, epcc.ErrOverflow, err)
+
+	price := epcc.ProductPrice{Amount: 1999, Currency: "USD", IncludesTax: true}
+	assert.Equal(t, epcc.NewMoney(1999, "USD"), price.Money())
+	assert.Equal(t, price, price.Money().ProductPrice(true))
+	assert.Equal(t, "1999 USD", price.Money().String())
+}
+
+var storeCurrencies = []epcc.Currency{
+	{Code: "USD", ExchangeRate: 1, DecimalPlaces: 2, Default: true, Enabled: true},
+	{Code: "EUR", ExchangeRate: 1.13, DecimalPlaces: 2, Enabled: true},
+	{Code: "GBP", ExchangeRate: 0.79, DecimalPlaces: 2, Enabled: true},
+	{Code: "JPY", ExchangeRate: 104.5, DecimalPlaces: 0, Enabled: true},
+	{Code: "BHD", ExchangeRate: 0.377, DecimalPlaces: 3, Enabled: true},
+	{Code: "CHF", ExchangeRate: 0.91, DecimalPlaces: 2, Enabled: false},
+}
+
+func TestConverterConvert(t *testing.T) {
+	converter := epcc.NewConverter(storeCurrencies)
+
+	tests := []struct {
+		money     epcc.Money
+		to        string
+		converted epcc.Money
+		err       error
+	}{
+		{epcc.NewMoney(1000, "USD"), "EUR", epcc.NewMoney(1130, "EUR"), nil},
+		// 0.1 * 1.13 is 0.113 exactly, so it rounds down rather than drifting.
+		{epcc.NewMoney(10, "USD"), "EUR", epcc.NewMoney(11, "EUR"), nil},
+		// 50 * 1.13 = 56.5 rounds half away from zero.
+		{epcc.NewMoney(50, "USD"), "EUR", epcc.NewMoney(57, "EUR"), nil},
+		{epcc.NewMoney(-50, "USD"), "EUR", epcc.NewMoney(-57, "EUR"), nil},
+		{epcc.NewMoney(1999, "USD"), "JPY", epcc.NewMoney(2089, "JPY"), nil},
+		{epcc.NewMoney(2089, "JPY"), "USD", epcc.NewMoney(1999, "USD"), nil},
+		{epcc.NewMoney(1000, "USD"), "BHD", epcc.NewMoney(3770, "BHD"), nil},
+		{epcc.NewMoney(1130, "EUR"), "GBP", epcc.NewMoney(790, "GBP"), nil},
+		{epcc.NewMoney(1000, "usd"), "usd", epcc.NewMoney(1000, "USD"), nil},
+		{epcc.NewMoney(1000, "USD"), "AUD", epcc.Money{}, errors.New("error unknown currency AUD")},
+	}
+
+	for _, test := range tests {
+		converted, err := converter.Convert(test.money, test.to)
+		assert.Equal(t, test.converted, converted, test.money.String()+" to "+test.to)
+		assert.Equal(t, test.err, err)
+	}
+
+	invalid := epcc.NewConverter([]epcc.Currency{{Code: "USD", ExchangeRate: 1}, {Code: "XXX"}})
+	_, err := invalid.Convert(epcc.NewMoney(1, "USD"), "XXX")
+	assert.Equal(t, errors.New("error currency XXX has invalid exchange rate 0"), err)
+}
+
+func TestConverterFillPrices(t *testing.T) {
+	converter := epcc.NewConverter(storeCurrencies)
+
+	product := epcc.Product{
+		Price: []epcc.ProductPrice{
+			{Amount: 1000, Currency: "USD", IncludesTax: true},
+			{Amount: 900, Currency: "GBP", IncludesTax: true},
+		},
+	}
+
+	err := converter.FillPrices(&product)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []epcc.ProductPrice{
+		{Amount: 1000, Currency: "USD", IncludesTax: true},
+		{Amount: 900, Currency: "GBP", IncludesTax: true},
+		{Amount: 1130, Currency: "EUR", IncludesTax: true},
+		{Amount: 1045, Currency: "JPY", IncludesTax: true},
+		{Amount: 3770, Currency: "BHD", IncludesTax: true},
+	}, product.Price)
+
+	noDefaultPrice := epcc.Product{Price: []epcc.ProductPrice{{Amount: 900, Currency: "GBP"}}}
+	err = converter.FillPrices(&noDefaultPrice)
+	assert.Equal(t, errors.New("error product has no price in default currency USD"), err)
+
+	noDefault := epcc.NewConverter(storeCurrencies[1:])
+	err = noDefault.FillPrices(&product)
+	assert.Equal(t, errors.New("error no default currency"), err)
+}
@@ -0,0 +1,74 @@
+package epcc
+
+// OrderData contains the data for a single order
+type OrderData struct {
+	Data Order `json:"data"`
+}
+
+// OrdersData contains the data for multiple orders
+type OrdersData struct {
+	Data []Order `json:"data"`
+}
+
+// Order represents an order
+type Order struct {
+	ID              string             `json:"id,omitempty"`
+	Type            string             `json:"type"`
+	Status          string             `json:"status,omitempty"`
+	Payment         string             `json:"payment,omitempty"`
+	Shipping        string             `json:"shipping,omitempty"`
+	Customer        OrderCustomer      `json:"customer,omitempty"`
+	ShippingAddress OrderAddress       `json:"shipping_address,omitempty"`
+	BillingAddress  OrderAddress       `json:"billing_address,omitempty"`
+	Links           Links              `json:"links,omitempty"`
+	Meta            OrderMeta          `json:"meta,omitempty"`
+	Relationships   OrderRelationships `json:"relationships,omitempty"`
+}
+
+// OrderCustomer is the customer who placed an order
+type OrderCustomer struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// OrderAddress is a shipping or billing address of an order
+type OrderAddress struct {
+	FirstName    string `json:"first_name,omitempty"`
+	LastName     string `json:"last_name,omitempty"`
+	PhoneNumber  string `json:"phone_number,omitempty"`
+	CompanyName  string `json:"company_name,omitempty"`
+	Line1        string `json:"line_1,omitempty"`
+	Line2        string `json:"line_2,omitempty"`
+	City         string `json:"city,omitempty"`
+	Postcode     string `json:"postcode,omitempty"`
+	County       string `json:"county,omitempty"`
+	Country      string `json:"country,omitempty"`
+	Instructions string `json:"instructions,omitempty"`
+}
+
+// OrderMeta contains extra data for an order
+type OrderMeta struct {
+	DisplayPrice OrderDisplayPrice `json:"display_price,omitempty"`
+	Timestamps   Timestamps        `json:"timestamps,omitempty"`
+}
+
+// OrderDisplayPrice contains the totals of an order
+type OrderDisplayPrice struct {
+	WithTax    DisplayPrice `json:"with_tax,omitempty"`
+	WithoutTax DisplayPrice `json:"without_tax,omitempty"`
+	Tax        DisplayPrice `json:"tax,omitempty"`
+}
+
+// DisplayPrice is an amount in a currency with its formatted representation
+type DisplayPrice struct {
+	Amount    int    `json:"amount"`
+	Currency  string `json:"currency"`
+	Formatted string `json:"formatted"`
+}
+
+// OrderRelationships represents the relationships that can exist for an order
+type OrderRelationships struct {
+	Items    RelationshipItems `json:"items,omitempty"`
+	Customer RelationshipItem  `json:"customer,omitempty"`
+	Account  RelationshipItem  `json:"account,omitempty"`
+}
@@ -0,0 +1,65 @@
+package epcc
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+)
+
+// listMeta is the pagination of a list response.
+type listMeta struct {
+	Meta struct {
+		Page struct {
+			Limit   int `json:"limit"`
+			Offset  int `json:"offset"`
+			Current int `json:"current"`
+			Total   int `json:"total"` // Total is the number of pages.
+		} `json:"page"`
+	} `json:"meta"`
+}
+
+// getPages requests every page of a list, calling page with the body of each one.
+// The first page is requested from path as it is, later pages are requested with page[offset]
+// until meta.page.total is reached. A response without pagination is the only page.
+func getPages(client *Client, path string, page func(body []byte) (count int, err error)) error {
+	for {
+		body, err := client.DoRequest("GET", path, nil)
+		if err != nil {
+			return err
+		}
+
+		count, err := page(body)
+		if err != nil {
+			return err
+		}
+
+		var meta listMeta
+		if err := json.Unmarshal(body, &meta); err != nil {
+			return err
+		}
+		current := meta.Meta.Page
+		if count == 0 || current.Current >= current.Total {
+			return nil
+		}
+
+		offset := current.Offset + current.Limit
+		if current.Limit == 0 {
+			offset = current.Offset + count
+		}
+		if path, err = withPageOffset(path, offset); err != nil {
+			return err
+		}
+	}
+}
+
+// withPageOffset returns a path with page[offset] set, keeping the rest of its query.
+func withPageOffset(path string, offset int) (string, error) {
+	pathURL, err := url.Parse(path)
+	if err != nil {
+		return "", err
+	}
+	query := pathURL.Query()
+	query.Set("page[offset]", strconv.Itoa(offset))
+	pathURL.RawQuery = query.Encode()
+	return pathURL.String(), nil
+}
@@ -3,8 +3,11 @@ package epcc
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
 )
 
 // Products is used to access the Products endpoints.
@@ -12,23 +15,74 @@ var Products products
 
 type products struct{}
 
-// GetAll fetches all products
+// GetAll fetches all products, requesting every page of the results.
 func (products) GetAll(client *Client) (*ProductsData, error) {
+	return Products.GetAllFiltered(client, Filter{})
+}
+
+// GetAllFiltered fetches all products matching a filter, requesting every page of the results.
+func (products) GetAllFiltered(client *Client, filter Filter) (*ProductsData, error) {
 	path := fmt.Sprintf("/v2/products")
+	if !filter.IsEmpty() {
+		path = fmt.Sprintf("/v2/products?filter=%s", url.QueryEscape(filter.String()))
+	}
 
-	body, err := client.DoRequest("GET", path, nil)
+	var products ProductsData
+	err := getPages(client, path, func(body []byte) (int, error) {
+		var page ProductsData
+		if err := json.Unmarshal(body, &page); err != nil {
+			return 0, err
+		}
+		products.Data = append(products.Data, page.Data...)
+		return len(page.Data), nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	var products ProductsData
-	if err := json.Unmarshal(body, &products); err != nil {
+	if err := checkProductEnums(client, products.Data...); err != nil {
 		return nil, err
 	}
 
 	return &products, nil
 }
 
+// GetUpdatedSince fetches every product updated after a time, oldest update first.
+func (products) GetUpdatedSince(client *Client, since time.Time) (*ProductsData, error) {
+	products, err := Products.GetAllFiltered(client, Filter{}.UpdatedSince(since))
+	if err != nil {
+		return nil, err
+	}
+
+	// The server filter is to the second, so check each timestamp exactly.
+	type updatedProduct struct {
+		product   Product
+		updatedAt time.Time
+	}
+
+	var updated []updatedProduct
+	for _, product := range products.Data {
+		updatedAt, err := product.Meta.Timestamps.Updated()
+		if err != nil {
+			return nil, err
+		}
+		if updatedAt.After(since) {
+			updated = append(updated, updatedProduct{product, updatedAt})
+		}
+	}
+
+	sort.SliceStable(updated, func(i, j int) bool {
+		return updated[i].updatedAt.Before(updated[j].updatedAt)
+	})
+
+	sorted := make([]Product, len(updated))
+	for i := range updated {
+		sorted[i] = updated[i].product
+	}
+
+	return &ProductsData{Data: sorted}, nil
+}
+
 // Get fetches a single product
 func (products) Get(client *Client, productID string) (*ProductData, error) {
 	path := fmt.Sprintf("/v2/products/%s", productID)
@@ -43,11 +97,22 @@ func (products) Get(client *Client, productID string) (*ProductData, error) {
 		return nil, err
 	}
 
+	if err := checkProductEnums(client, product.Data); err != nil {
+		return nil, err
+	}
+
 	return &product, nil
 }
 
 // Create creates a product
 func (products) Create(client *Client, product *Product) (*ProductData, error) {
+	if err := validatePayload(client, product.Validate); err != nil {
+		return nil, err
+	}
+
+	if err := checkProductEnums(client, *product); err != nil {
+		return nil, err
+	}
 
 	productData := ProductData{
 		Data: *product,
@@ -80,6 +145,14 @@ func (products) Update(client *Client, product *Product) (*ProductData, error) {
 		return nil, errors.New("error productID is required")
 	}
 
+	if err := validatePayload(client, product.ValidateUpdate); err != nil {
+		return nil, err
+	}
+
+	if err := checkProductEnums(client, *product); err != nil {
+		return nil, err
+	}
+
 	productData := ProductData{
 		Data: *product,
 	}
@@ -103,3 +176,106 @@ func (products) Update(client *Client, product *Product) (*ProductData, error) {
 
 	return &updatedProduct, nil
 }
+
+// Delete deletes a product.
+func (products) Delete(client *Client, productID string) error {
+	path := fmt.Sprintf("/v2/products/%s", productID)
+
+	if _, err := client.DoRequest("DELETE", path, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetWithExtension fetches a single product and decodes its flow fields into ext.
+// ext must be a pointer to a struct with fields tagged `flow:"slug"`.
+func (products) GetWithExtension(client *Client, productID string, ext interface{}) (*ProductData, error) {
+	path := fmt.Sprintf("/v2/products/%s", productID)
+
+	body, err := client.DoRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var product ProductData
+	if err := json.Unmarshal(body, &product); err != nil {
+		return nil, err
+	}
+
+	if err := checkProductEnums(client, product.Data); err != nil {
+		return nil, err
+	}
+
+	// Flow fields are not fields of Product, so they are kept as unknown attributes.
+	if err := decodeExtension(product.Data.attributes, ext); err != nil {
+		return nil, err
+	}
+
+	return &product, nil
+}
+
+// CreateWithExtension creates a product, sending the flow fields of ext alongside it.
+func (products) CreateWithExtension(client *Client, product *Product, ext interface{}) (*ProductData, error) {
+	if err := validatePayload(client, product.Validate); err != nil {
+		return nil, err
+	}
+
+	if err := checkProductEnums(client, *product); err != nil {
+		return nil, err
+	}
+
+	jsonPayload, err := withExtension(product, ext)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/v2/products")
+
+	body, err := client.DoRequest("POST", path, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, err
+	}
+
+	var newProduct ProductData
+	if err := json.Unmarshal(body, &newProduct); err != nil {
+		return nil, err
+	}
+
+	return &newProduct, nil
+}
+
+// UpdateWithExtension updates a product, sending the flow fields of ext alongside it.
+func (products) UpdateWithExtension(client *Client, product *Product, ext interface{}) (*ProductData, error) {
+
+	if product.ID == "" {
+		return nil, errors.New("error productID is required")
+	}
+
+	if err := validatePayload(client, product.ValidateUpdate); err != nil {
+		return nil, err
+	}
+
+	if err := checkProductEnums(client, *product); err != nil {
+		return nil, err
+	}
+
+	jsonPayload, err := withExtension(product, ext)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/v2/products/%s", product.ID)
+
+	body, err := client.DoRequest("PUT", path, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedProduct ProductData
+	if err := json.Unmarshal(body, &updatedProduct); err != nil {
+		return nil, err
+	}
+
+	return &updatedProduct, nil
+}
@@ -3,6 +3,7 @@ package epcc_test
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/Rosalita/go-epcc-client"
+	"github.com/Rosalita/go-epcc-client/epcctest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -540,18 +542,16 @@ func fakeHandleProductsCreate(rw http.ResponseWriter, req *http.Request) {
 		rw.Write([]byte(responseJSON))
 	case req.URL.String() == "/v2/products" && req.Method == "POST" && strings.Contains(buffer.String(), `"name":"Invalid product"`):
 		responseJSON := `{
-			{
-				"errors": [
-					{
-						"title": "Failed Validation",
-						"detail": "The data.weight.unit field is required when data.weight is present."
-					},
-					{
-						"title": "Failed Validation",
-						"detail": "The data.weight.value field is required when data.weight is present."
-					}
-				]
-			}
+			"errors": [
+				{
+					"title": "Failed Validation",
+					"detail": "The data.weight.unit field is required when data.weight is present."
+				},
+				{
+					"title": "Failed Validation",
+					"detail": "The data.weight.value field is required when data.weight is present."
+				}
+			]
 		}`
 		rw.WriteHeader(422)
 		rw.Write([]byte(responseJSON))
@@ -630,7 +630,13 @@ func TestProductsCreate(t *testing.T) {
 		err         error
 	}{
 		{validNewProduct, &expectedProductData, nil},
-		{invalidProductWeight, nil, errors.New("status code 422 is not ok")},
+		{invalidProductWeight, nil, &epcc.APIError{
+			StatusCode: 422,
+			Errors: []epcc.ErrorDetail{
+				{Title: "Failed Validation", Detail: "The data.weight.unit field is required when data.weight is present."},
+				{Title: "Failed Validation", Detail: "The data.weight.value field is required when data.weight is present."},
+			},
+		}},
 	}
 
 	// Create a new client and configure it to use test server instead of the real API endpoint.
@@ -791,3 +797,242 @@ func TestProductsUpdate(t *testing.T) {
 		assert.Equal(t, test.err, err)
 	}
 }
+
+type paperExtension struct {
+	Colour         string `flow:"colour"`
+	WarrantyMonths int    `flow:"warranty_months,omitempty"`
+}
+
+func fakeHandleProductsWithExtension(rw http.ResponseWriter, req *http.Request) {
+	var buffer bytes.Buffer
+	_, err := buffer.ReadFrom(req.Body)
+	if err != nil {
+		rw.WriteHeader(500)
+		return
+	}
+
+	responseJSON := `{
+		"data": {
+			"type": "product",
+			"id": "64e4ce0d-c8d6-4c17-a929-de111ecc5140",
+			"name": "Origami Cat",
+			"slug": "origami-cat",
+			"sku": "origami-cat",
+			"description": "The Origami Cat is considered lucky.",
+			"manage_stock": false,
+			"status": "draft",
+			"commodity_type": "physical",
+			"price": [],
+			"colour": "green",
+			"warranty_months": 12
+		}
+	}`
+
+	switch {
+	case req.URL.String() == "/v2/products/validProductID" && req.Method == "GET":
+		rw.WriteHeader(200)
+		rw.Write([]byte(responseJSON))
+	case req.URL.String() == "/v2/products" && req.Method == "POST" &&
+		strings.Contains(buffer.String(), `"colour":"green"`) &&
+		strings.Contains(buffer.String(), `"warranty_months":12`) &&
+		strings.Contains(buffer.String(), `"sku":"origami-cat"`):
+		rw.WriteHeader(201)
+		rw.Write([]byte(responseJSON))
+	case req.URL.String() == "/v2/products/64e4ce0d-c8d6-4c17-a929-de111ecc5140" && req.Method == "PUT" &&
+		strings.Contains(buffer.String(), `"colour":"green"`) &&
+		!strings.Contains(buffer.String(), `"warranty_months"`):
+		rw.WriteHeader(200)
+		rw.Write([]byte(responseJSON))
+	default:
+		rw.WriteHeader(500)
+	}
+}
+
+func TestProductsWithExtension(t *testing.T) {
+	expectedProductData := epcc.ProductData{
+		Data: epcc.Product{
+			ID:            "64e4ce0d-c8d6-4c17-a929-de111ecc5140",
+			Type:          "product",
+			Name:          "Origami Cat",
+			Slug:          "origami-cat",
+			SKU:           "origami-cat",
+			Description:   "The Origami Cat is considered lucky.",
+			ManageStock:   false,
+			Status:        "draft",
+			CommodityType: "physical",
+			Price:         []epcc.ProductPrice{},
+		},
+	}
+	err := expectedProductData.Data.SetAttribute("colour", "green")
+	assert.Equal(t, nil, err)
+	err = expectedProductData.Data.SetAttribute("warranty_months", 12)
+	assert.Equal(t, nil, err)
+
+	// Create a new client and configure it to use test server instead of the real API endpoint.
+	testServer := httptest.NewServer(http.HandlerFunc(fakeHandleProductsWithExtension))
+	options := epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	}
+	client := epcc.NewClient(options)
+
+	var ext paperExtension
+	productData, err := epcc.Products.GetWithExtension(client, "validProductID", &ext)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &expectedProductData, productData)
+	assert.Equal(t, paperExtension{Colour: "green", WarrantyMonths: 12}, ext)
+
+	newProduct := epcc.Product{
+		Type:          "product",
+		Name:          "Origami Cat",
+		Slug:          "origami-cat",
+		SKU:           "origami-cat",
+		Status:        "draft",
+		CommodityType: "physical",
+	}
+	productData, err = epcc.Products.CreateWithExtension(client, &newProduct, ext)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &expectedProductData, productData)
+
+	update := epcc.Product{
+		ID:   "64e4ce0d-c8d6-4c17-a929-de111ecc5140",
+		Type: "product",
+	}
+	productData, err = epcc.Products.UpdateWithExtension(client, &update, paperExtension{Colour: "green"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &expectedProductData, productData)
+
+	_, err = epcc.Products.UpdateWithExtension(client, &newProduct, ext)
+	assert.Equal(t, errors.New("error productID is required"), err)
+}
+
+func fakeHandleProductsUpdatedSince(rw http.ResponseWriter, req *http.Request) {
+	switch {
+	case req.URL.Path == "/v2/products" && req.Method == "GET" && req.URL.Query().Get("filter") == "gt(updated_at,2020-09-01T00:00:00Z)":
+		responseJSON := `{
+			"data": [
+				{
+					"type": "product",
+					"id": "later",
+					"meta": {"timestamps": {"updated_at": "2020-09-03T15:09:27+00:00"}}
+				},
+				{
+					"type": "product",
+					"id": "sameSecond",
+					"meta": {"timestamps": {"updated_at": "2020-09-01T00:00:00.000Z"}}
+				},
+				{
+					"type": "product",
+					"id": "earlier",
+					"meta": {"timestamps": {"updated_at": "2020-09-02T10:00:00+00:00"}}
+				}
+			]
+		}`
+		rw.WriteHeader(200)
+		rw.Write([]byte(responseJSON))
+	default:
+		rw.WriteHeader(500)
+	}
+}
+
+func TestProductsGetUpdatedSince(t *testing.T) {
+	// Create a new client and configure it to use test server instead of the real API endpoint.
+	testServer := httptest.NewServer(http.HandlerFunc(fakeHandleProductsUpdatedSince))
+	options := epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	}
+	client := epcc.NewClient(options)
+
+	productsData, err := epcc.Products.GetUpdatedSince(client, time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, nil, err)
+
+	var ids []string
+	for _, product := range productsData.Data {
+		ids = append(ids, product.ID)
+	}
+	assert.Equal(t, []string{"earlier", "later"}, ids)
+}
+
+func TestProductsGetUpdatedSincePages(t *testing.T) {
+	server := epcctest.NewServer()
+	defer server.Close()
+
+	// Each product is updated a second after the one before.
+	since := time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC)
+	now := since.Add(-5 * time.Second)
+	server.Now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+	var expected []string
+	for i := 0; i < 40; i++ {
+		product := server.AddProduct(epcc.Product{Name: fmt.Sprintf("Origami %d", i), SKU: fmt.Sprintf("ORI-%d", i)})
+		if i >= 5 {
+			expected = append(expected, product.ID)
+		}
+	}
+
+	// The 35 matching products are more than a page, every one is returned.
+	productsData, err := epcc.Products.GetUpdatedSince(server.Client(), since)
+	assert.Equal(t, nil, err)
+
+	var ids []string
+	for _, product := range productsData.Data {
+		ids = append(ids, product.ID)
+	}
+	assert.Equal(t, expected, ids)
+	requests := server.Requests()
+	assert.Contains(t, requests[len(requests)-1].Query, "page%5Boffset%5D=25")
+}
+
+func fakeHandleProductsDelete(rw http.ResponseWriter, req *http.Request) {
+	switch {
+	case req.URL.String() == "/v2/products/validProductID" && req.Method == "DELETE":
+		rw.WriteHeader(204)
+	case req.URL.String() == "/v2/products/notFound" && req.Method == "DELETE":
+		responseJSON := `{
+			"errors":[{
+				"status":404,
+				"title":"Not Found",
+				"detail":"No product found"
+			}]
+		}`
+		rw.WriteHeader(404)
+		rw.Write([]byte(responseJSON))
+	default:
+		rw.WriteHeader(500)
+	}
+}
+
+func TestProductsDelete(t *testing.T) {
+	tests := []struct {
+		productID string
+		err       error
+	}{
+		{"validProductID", nil},
+		{"notFound", &epcc.APIError{
+			StatusCode: 404,
+			Errors: []epcc.ErrorDetail{
+				{Status: 404, Title: "Not Found", Detail: "No product found"},
+			},
+		}},
+	}
+
+	// Create a new client and configure it to use test server instead of the real API endpoint.
+	testServer := httptest.NewServer(http.HandlerFunc(fakeHandleProductsDelete))
+
+	options := epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	}
+	client := epcc.NewClient(options)
+
+	for _, test := range tests {
+		err := epcc.Products.Delete(client, test.productID)
+		assert.Equal(t, test.err, err)
+	}
+}
@@ -0,0 +1,201 @@
+package epcc
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultBulkConcurrency is how many requests a bulk operation makes at once when no concurrency is set.
+const DefaultBulkConcurrency = 4
+
+// ErrBulkSkipped is the error of an item which was not attempted because an earlier item failed.
+var ErrBulkSkipped = errors.New("error skipped after an earlier failure")
+
+// BulkOptions configures a bulk operation.
+type BulkOptions struct {
+	Concurrency       int                // Concurrency is how many requests are made at once, defaults to DefaultBulkConcurrency.
+	RequestsPerSecond int                // RequestsPerSecond limits how often requests are started, 0 is unlimited.
+	ContinueOnError   bool               // ContinueOnError attempts every item even after an item fails.
+	Progress          func(BulkProgress) // Progress is called after each item is finished, one call at a time.
+	Resolver          *SKUResolver       // Resolver is used by UpsertBySKU to find existing products, a new one is used when nil.
+}
+
+// BulkProgress reports how far through a bulk operation is.
+type BulkProgress struct {
+	Total     int
+	Completed int
+	Failed    int
+}
+
+// BulkAction is what a bulk operation did with an item.
+type BulkAction string
+
+// Actions taken by bulk operations.
+const (
+	BulkCreated BulkAction = "created"
+	BulkUpdated BulkAction = "updated"
+)
+
+// BulkResult is the result of a single item of a bulk operation.
+// Index is the position of the item in the products passed to the operation.
+type BulkResult struct {
+	Index   int
+	SKU     string
+	Action  BulkAction
+	Product *ProductData
+	Err     error
+}
+
+// BulkError is returned by a bulk operation when any item fails.
+type BulkError struct {
+	Failed []BulkResult
+}
+
+// Error summarises the failed items and the error of the first.
+func (e *BulkError) Error() string {
+	return fmt.Sprintf("%d items failed, first error at item %d: %s", len(e.Failed), e.Failed[0].Index, e.Failed[0].Err)
+}
+
+// CreateMany creates products concurrently.
+// A result is returned for every product, in the same order as products.
+func (products) CreateMany(client *Client, products []Product, options BulkOptions) ([]BulkResult, error) {
+	return runBulk(len(products), options, func(i int) BulkResult {
+		return BulkResult{Index: i, SKU: products[i].SKU, Action: BulkCreated}
+	}, func(i int, result *BulkResult) {
+		product := products[i]
+		result.Product, result.Err = Products.Create(client, &product)
+	})
+}
+
+// UpdateMany updates products concurrently, each product must have an ID.
+// A result is returned for every product, in the same order as products.
+func (products) UpdateMany(client *Client, products []Product, options BulkOptions) ([]BulkResult, error) {
+	return runBulk(len(products), options, func(i int) BulkResult {
+		return BulkResult{Index: i, SKU: products[i].SKU, Action: BulkUpdated}
+	}, func(i int, result *BulkResult) {
+		product := products[i]
+		result.Product, result.Err = Products.Update(client, &product)
+	})
+}
+
+// UpsertBySKU updates the products which already exist with the same SKU and creates the rest.
+// The IDs of existing products are resolved in batches before any product is sent.
+// A result is returned for every product, in the same order as products.
+func (products) UpsertBySKU(client *Client, products []Product, options BulkOptions) ([]BulkResult, error) {
+	resolver := options.Resolver
+	if resolver == nil {
+		resolver = NewSKUResolver(client)
+	}
+
+	skus := make([]string, 0, len(products))
+	for _, product := range products {
+		if product.SKU == "" {
+			return nil, errors.New("error sku is required to upsert a product")
+		}
+		skus = append(skus, product.SKU)
+	}
+
+	ids, err := resolver.Resolve(skus...)
+	if err != nil {
+		return nil, err
+	}
+
+	return runBulk(len(products), options, func(i int) BulkResult {
+		result := BulkResult{Index: i, SKU: products[i].SKU, Action: BulkCreated}
+		if _, ok := ids[products[i].SKU]; ok {
+			result.Action = BulkUpdated
+		}
+		return result
+	}, func(i int, result *BulkResult) {
+		product := products[i]
+
+		if result.Action == BulkUpdated {
+			product.ID = ids[product.SKU]
+			result.Product, result.Err = Products.Update(client, &product)
+			return
+		}
+
+		result.Product, result.Err = Products.Create(client, &product)
+		if result.Err == nil {
+			resolver.Add(product.SKU, result.Product.Data.ID)
+		}
+	})
+}
+
+// runBulk calls do for each of total items using a pool of workers.
+// item returns the result of an item before it is attempted, so items which are skipped still have their SKU and action.
+func runBulk(total int, options BulkOptions, item func(i int) BulkResult, do func(i int, result *BulkResult)) ([]BulkResult, error) {
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+
+	var limiter <-chan time.Time
+	if options.RequestsPerSecond > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(options.RequestsPerSecond))
+		defer ticker.Stop()
+		limiter = ticker.C
+	}
+
+	results := make([]BulkResult, total)
+	progress := BulkProgress{Total: total}
+	stopped := false
+	var mu sync.Mutex
+
+	items := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range items {
+				mu.Lock()
+				skip := stopped
+				mu.Unlock()
+
+				result := item(i)
+				if skip {
+					result.Err = ErrBulkSkipped
+				} else {
+					if limiter != nil {
+						<-limiter
+					}
+					do(i, &result)
+				}
+
+				mu.Lock()
+				results[i] = result
+				progress.Completed++
+				if result.Err != nil {
+					progress.Failed++
+					if !options.ContinueOnError {
+						stopped = true
+					}
+				}
+				if options.Progress != nil {
+					options.Progress(progress)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := 0; i < total; i++ {
+		items <- i
+	}
+	close(items)
+	wg.Wait()
+
+	var failed []BulkResult
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	if len(failed) > 0 {
+		return results, &BulkError{Failed: failed}
+	}
+	return results, nil
+}
@@ -0,0 +1,249 @@
+package epcc_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeProductsBulk stores products in memory. Products with the SKU "BAD" are rejected
+// and the first request for each SKU in rateLimited receives a 429.
+type fakeProductsBulk struct {
+	mu          sync.Mutex
+	products    map[string]epcc.Product
+	rateLimited map[string]bool
+	nextID      int
+	inFlight    int
+	maxInFlight int
+}
+
+func newFakeProductsBulk(existing ...epcc.Product) *fakeProductsBulk {
+	fake := &fakeProductsBulk{
+		products:    map[string]epcc.Product{},
+		rateLimited: map[string]bool{},
+	}
+	for _, product := range existing {
+		fake.products[product.ID] = product
+	}
+	return fake
+}
+
+func (f *fakeProductsBulk) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	f.inFlight++
+	if f.inFlight > f.maxInFlight {
+		f.maxInFlight = f.inFlight
+	}
+	f.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.inFlight--
+
+	if req.Method == "GET" {
+		filter := req.URL.Query().Get("filter")
+		skus := strings.Split(strings.TrimSuffix(strings.TrimPrefix(filter, "in(sku,"), ")"), ",")
+		matched := []epcc.Product{}
+		for _, product := range f.products {
+			for _, sku := range skus {
+				if product.SKU == sku {
+					matched = append(matched, product)
+				}
+			}
+		}
+		rw.WriteHeader(200)
+		json.NewEncoder(rw).Encode(epcc.ProductsData{Data: matched})
+		return
+	}
+
+	var productData epcc.ProductData
+	json.NewDecoder(req.Body).Decode(&productData)
+	product := productData.Data
+
+	if product.SKU == "BAD" {
+		rw.WriteHeader(422)
+		rw.Write([]byte(`{"errors":[{"status":422,"title":"Failed Validation","detail":"bad product"}]}`))
+		return
+	}
+
+	if f.rateLimited[product.SKU] {
+		delete(f.rateLimited, product.SKU)
+		rw.WriteHeader(429)
+		return
+	}
+
+	switch {
+	case req.Method == "POST" && req.URL.Path == "/v2/products":
+		f.nextID++
+		product.ID = fmt.Sprintf("new-%d", f.nextID)
+		f.products[product.ID] = product
+		rw.WriteHeader(201)
+	case req.Method == "PUT" && req.URL.Path == "/v2/products/"+product.ID:
+		if _, ok := f.products[product.ID]; !ok {
+			rw.WriteHeader(404)
+			return
+		}
+		f.products[product.ID] = product
+		rw.WriteHeader(200)
+	default:
+		rw.WriteHeader(500)
+		return
+	}
+	json.NewEncoder(rw).Encode(epcc.ProductData{Data: product})
+}
+
+func newBulkTestClient(fake *fakeProductsBulk) *epcc.Client {
+	// Create a new client and configure it to use test server instead of the real API endpoint.
+	testServer := httptest.NewServer(fake)
+	options := epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: time.Second,
+	}
+	return epcc.NewClient(options)
+}
+
+func TestProductsCreateMany(t *testing.T) {
+	fake := newFakeProductsBulk()
+	fake.rateLimited["SKU-3"] = true
+	client := newBulkTestClient(fake)
+
+	var products []epcc.Product
+	for i := 0; i < 12; i++ {
+		products = append(products, epcc.Product{Type: "product", SKU: fmt.Sprintf("SKU-%d", i)})
+	}
+
+	var updates []epcc.BulkProgress
+	results, err := epcc.Products.CreateMany(client, products, epcc.BulkOptions{
+		Concurrency: 3,
+		Progress: func(progress epcc.BulkProgress) {
+			updates = append(updates, progress)
+		},
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 12, len(results))
+	assert.Equal(t, 12, len(fake.products))
+	assert.True(t, fake.maxInFlight > 1 && fake.maxInFlight <= 3)
+
+	for i, result := range results {
+		assert.Equal(t, i, result.Index)
+		assert.Equal(t, epcc.BulkCreated, result.Action)
+		assert.Equal(t, products[i].SKU, result.Product.Data.SKU)
+	}
+
+	assert.Equal(t, 12, len(updates))
+	assert.Equal(t, epcc.BulkProgress{Total: 12, Completed: 12}, updates[11])
+}
+
+func TestProductsUpdateManyContinueOnError(t *testing.T) {
+	fake := newFakeProductsBulk(
+		epcc.Product{Type: "product", ID: "a", SKU: "A"},
+		epcc.Product{Type: "product", ID: "b", SKU: "B"},
+	)
+	client := newBulkTestClient(fake)
+
+	products := []epcc.Product{
+		{Type: "product", ID: "a", SKU: "A", Name: "Updated A"},
+		{Type: "product", ID: "b", SKU: "BAD"},
+		{Type: "product", SKU: "C"},
+		{Type: "product", ID: "missing", SKU: "D"},
+	}
+
+	results, err := epcc.Products.UpdateMany(client, products, epcc.BulkOptions{ContinueOnError: true})
+
+	badProduct := &epcc.APIError{
+		StatusCode: 422,
+		Errors: []epcc.ErrorDetail{
+			{Status: 422, Title: "Failed Validation", Detail: "bad product"},
+		},
+	}
+	assert.Equal(t, nil, results[0].Err)
+	assert.Equal(t, "Updated A", fake.products["a"].Name)
+	for i, result := range results {
+		assert.Equal(t, products[i].SKU, result.SKU)
+		assert.Equal(t, epcc.BulkUpdated, result.Action)
+	}
+	assert.Equal(t, badProduct, results[1].Err)
+	assert.Equal(t, "error productID is required", results[2].Err.Error())
+	assert.Equal(t, &epcc.APIError{StatusCode: 404}, results[3].Err)
+
+	bulkError, ok := err.(*epcc.BulkError)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, 3, len(bulkError.Failed))
+	assert.Equal(t, "3 items failed, first error at item 1: status code 422 is not ok", err.Error())
+}
+
+func TestProductsBulkStopsOnError(t *testing.T) {
+	fake := newFakeProductsBulk()
+	client := newBulkTestClient(fake)
+
+	products := []epcc.Product{
+		{Type: "product", SKU: "BAD"},
+		{Type: "product", SKU: "A"},
+		{Type: "product", SKU: "B"},
+	}
+
+	results, err := epcc.Products.CreateMany(client, products, epcc.BulkOptions{Concurrency: 1})
+	assert.IsType(t, &epcc.BulkError{}, err)
+	assert.IsType(t, &epcc.APIError{}, results[0].Err)
+	assert.Equal(t, epcc.BulkResult{Index: 1, SKU: "A", Action: epcc.BulkCreated, Err: epcc.ErrBulkSkipped}, results[1])
+	assert.Equal(t, epcc.BulkResult{Index: 2, SKU: "B", Action: epcc.BulkCreated, Err: epcc.ErrBulkSkipped}, results[2])
+	assert.Equal(t, 0, len(fake.products))
+
+	// Skipped items of an upsert have the action they would have taken.
+	fake = newFakeProductsBulk(epcc.Product{Type: "product", ID: "a", SKU: "A"})
+	client = newBulkTestClient(fake)
+	results, err = epcc.Products.UpsertBySKU(client, products, epcc.BulkOptions{Concurrency: 1})
+	assert.IsType(t, &epcc.BulkError{}, err)
+	assert.Equal(t, "BAD", results[0].SKU)
+	assert.Equal(t, epcc.BulkCreated, results[0].Action)
+	assert.Equal(t, epcc.BulkResult{Index: 1, SKU: "A", Action: epcc.BulkUpdated, Err: epcc.ErrBulkSkipped}, results[1])
+	assert.Equal(t, epcc.BulkResult{Index: 2, SKU: "B", Action: epcc.BulkCreated, Err: epcc.ErrBulkSkipped}, results[2])
+}
+
+func TestProductsUpsertBySKU(t *testing.T) {
+	fake := newFakeProductsBulk(
+		epcc.Product{Type: "product", ID: "a", SKU: "A", Name: "Old A"},
+	)
+	client := newBulkTestClient(fake)
+	resolver := epcc.NewSKUResolver(client)
+
+	products := []epcc.Product{
+		{Type: "product", SKU: "A", Name: "New A"},
+		{Type: "product", SKU: "B", Name: "New B"},
+	}
+
+	start := time.Now()
+	results, err := epcc.Products.UpsertBySKU(client, products, epcc.BulkOptions{
+		Resolver:          resolver,
+		RequestsPerSecond: 20,
+	})
+	assert.Equal(t, nil, err)
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+
+	assert.Equal(t, epcc.BulkUpdated, results[0].Action)
+	assert.Equal(t, "A", results[0].SKU)
+	assert.Equal(t, "a", results[0].Product.Data.ID)
+	assert.Equal(t, epcc.BulkCreated, results[1].Action)
+	assert.Equal(t, "B", results[1].SKU)
+	assert.Equal(t, "New A", fake.products["a"].Name)
+	assert.Equal(t, 2, len(fake.products))
+
+	// Created products are cached by the resolver, so a second upsert updates them.
+	id, err := resolver.ResolveOne("B")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, results[1].Product.Data.ID, id)
+
+	_, err = epcc.Products.UpsertBySKU(client, []epcc.Product{{Type: "product"}}, epcc.BulkOptions{})
+	assert.Equal(t, "error sku is required to upsert a product", err.Error())
+}
@@ -0,0 +1,103 @@
+package epcc
+
+import "fmt"
+
+// ProductStatus is the status of a product.
+type ProductStatus string
+
+// Product statuses.
+const (
+	ProductStatusLive  ProductStatus = "live"
+	ProductStatusDraft ProductStatus = "draft"
+)
+
+// CommodityType is the type of commodity a product is.
+type CommodityType string
+
+// Commodity types.
+const (
+	CommodityTypePhysical CommodityType = "physical"
+	CommodityTypeDigital  CommodityType = "digital"
+)
+
+// StockAvailability is the stock availability of a product.
+type StockAvailability string
+
+// Stock availabilities.
+const (
+	StockInStock    StockAvailability = "in-stock"
+	StockOutOfStock StockAvailability = "out-stock"
+	StockLimited    StockAvailability = "limited"
+)
+
+// Valid reports whether the status is a known product status.
+func (s ProductStatus) Valid() bool {
+	return s == ProductStatusLive || s == ProductStatusDraft
+}
+
+// Valid reports whether the commodity type is a known commodity type.
+func (c CommodityType) Valid() bool {
+	return c == CommodityTypePhysical || c == CommodityTypeDigital
+}
+
+// Valid reports whether the availability is a known stock availability.
+func (a StockAvailability) Valid() bool {
+	return a == StockInStock || a == StockOutOfStock || a == StockLimited
+}
+
+// ParseProductStatus parses a product status, which must match one exactly as the API is case sensitive.
+func ParseProductStatus(s string) (ProductStatus, error) {
+	status := ProductStatus(s)
+	if !status.Valid() {
+		return "", fmt.Errorf("error invalid product status %q", s)
+	}
+	return status, nil
+}
+
+// ParseCommodityType parses a commodity type, which must match one exactly as the API is case sensitive.
+func ParseCommodityType(s string) (CommodityType, error) {
+	commodityType := CommodityType(s)
+	if !commodityType.Valid() {
+		return "", fmt.Errorf("error invalid commodity type %q", s)
+	}
+	return commodityType, nil
+}
+
+// ParseStockAvailability parses a stock availability, which must match one exactly as the API is case sensitive.
+func ParseStockAvailability(s string) (StockAvailability, error) {
+	availability := StockAvailability(s)
+	if !availability.Valid() {
+		return "", fmt.Errorf("error invalid stock availability %q", s)
+	}
+	return availability, nil
+}
+
+// checkEnums returns an error if a product has a status, commodity type or stock availability
+// which is set but unknown. Empty values are allowed so partial updates can leave them out.
+func (p Product) checkEnums() error {
+	if p.Status != "" && !p.Status.Valid() {
+		return fmt.Errorf("error invalid product status %q", p.Status)
+	}
+	if p.CommodityType != "" && !p.CommodityType.Valid() {
+		return fmt.Errorf("error invalid commodity type %q", p.CommodityType)
+	}
+	if p.Meta.Stock.Availability != "" && !p.Meta.Stock.Availability.Valid() {
+		return fmt.Errorf("error invalid stock availability %q", p.Meta.Stock.Availability)
+	}
+	return nil
+}
+
+// checkProductEnums checks the enums of products when the client is in strict mode.
+// Decoding never rejects an unknown value, strict mode is this check made afterwards.
+func checkProductEnums(client *Client, products ...Product) error {
+	if !client.StrictEnums {
+		return nil
+	}
+
+	for _, product := range products {
+		if err := product.checkEnums(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
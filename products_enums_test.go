@@ -0,0 +1,148 @@
+package epcc_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProductEnums(t *testing.T) {
+	status, err := epcc.ParseProductStatus("live")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, epcc.ProductStatusLive, status)
+
+	_, err = epcc.ParseProductStatus("Live")
+	assert.Equal(t, errors.New(`error invalid product status "Live"`), err)
+
+	_, err = epcc.ParseProductStatus("published")
+	assert.Equal(t, errors.New(`error invalid product status "published"`), err)
+
+	commodityType, err := epcc.ParseCommodityType("digital")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, epcc.CommodityTypeDigital, commodityType)
+
+	_, err = epcc.ParseCommodityType("DIGITAL")
+	assert.Equal(t, errors.New(`error invalid commodity type "DIGITAL"`), err)
+
+	_, err = epcc.ParseCommodityType("phyiscal")
+	assert.Equal(t, errors.New(`error invalid commodity type "phyiscal"`), err)
+
+	availability, err := epcc.ParseStockAvailability("limited")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, epcc.StockLimited, availability)
+
+	_, err = epcc.ParseStockAvailability("In-Stock")
+	assert.Equal(t, errors.New(`error invalid stock availability "In-Stock"`), err)
+
+	_, err = epcc.ParseStockAvailability("backorder")
+	assert.Equal(t, errors.New(`error invalid stock availability "backorder"`), err)
+}
+
+func TestProductEnumsLenientDecode(t *testing.T) {
+	rawJSON := `{"type":"product","status":"archived","commodity_type":"service","meta":{"stock":{"level":1,"availability":"backorder"}}}`
+
+	var product epcc.Product
+	err := json.Unmarshal([]byte(rawJSON), &product)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, epcc.ProductStatus("archived"), product.Status)
+	assert.Equal(t, false, product.Status.Valid())
+	assert.Equal(t, epcc.CommodityType("service"), product.CommodityType)
+	assert.Equal(t, epcc.StockAvailability("backorder"), product.Meta.Stock.Availability)
+}
+
+func fakeHandleProductsEnums(rw http.ResponseWriter, req *http.Request) {
+	switch {
+	case req.URL.String() == "/v2/products/newStatus" && req.Method == "GET":
+		responseJSON := `{"data":{"type":"product","id":"newStatus","status":"archived","commodity_type":"physical"}}`
+		rw.WriteHeader(200)
+		rw.Write([]byte(responseJSON))
+	case req.URL.String() == "/v2/products" && req.Method == "POST":
+		responseJSON := `{"data":{"type":"product","id":"created","status":"live","commodity_type":"physical"}}`
+		rw.WriteHeader(201)
+		rw.Write([]byte(responseJSON))
+	default:
+		rw.WriteHeader(500)
+	}
+}
+
+func TestProductEnumsStrictMode(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(fakeHandleProductsEnums))
+
+	lenientClient := epcc.NewClient(epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	})
+
+	strictClient := epcc.NewClient(epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+		StrictEnums:       true,
+	})
+
+	productData, err := epcc.Products.Get(lenientClient, "newStatus")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, epcc.ProductStatus("archived"), productData.Data.Status)
+
+	_, err = epcc.Products.Get(strictClient, "newStatus")
+	assert.Equal(t, errors.New(`error invalid product status "archived"`), err)
+
+	typo := epcc.Product{Type: "product", Status: "Live", CommodityType: epcc.CommodityTypePhysical}
+	_, err = epcc.Products.Create(strictClient, &typo)
+	assert.Equal(t, errors.New(`error invalid product status "Live"`), err)
+
+	typo = epcc.Product{Type: "product", Status: epcc.ProductStatusLive, CommodityType: "phyiscal"}
+	_, err = epcc.Products.Create(strictClient, &typo)
+	assert.Equal(t, errors.New(`error invalid commodity type "phyiscal"`), err)
+
+	valid := epcc.Product{Type: "product", Status: epcc.ProductStatusLive, CommodityType: epcc.CommodityTypePhysical}
+	productData, err = epcc.Products.Create(strictClient, &valid)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "created", productData.Data.ID)
+}
+
+func TestProductEnumsStrictWebhook(t *testing.T) {
+	archivedEvent := `{"id":"archived","triggered_by":"product.updated","resources":"{\"data\":{\"type\":\"product\",\"id\":\"frog\",\"status\":\"archived\"}}"}`
+
+	var lenient, strict error
+	lenientHandler := epcc.NewWebhookHandler("secret")
+	lenientHandler.Handle(epcc.EventProductUpdated, func(e *epcc.WebhookEvent) error {
+		_, lenient = e.Product()
+		return lenient
+	})
+	strictHandler := epcc.NewWebhookHandler("secret")
+	strictHandler.StrictEnums = true
+	strictHandler.Handle(epcc.EventProductUpdated, func(e *epcc.WebhookEvent) error {
+		_, strict = e.Product()
+		return strict
+	})
+
+	assert.Equal(t, 200, sendWebhook(lenientHandler, "POST", "secret", archivedEvent))
+	assert.Equal(t, nil, lenient)
+	assert.Equal(t, 500, sendWebhook(strictHandler, "POST", "secret", archivedEvent))
+	assert.Equal(t, errors.New(`error invalid product status "archived"`), strict)
+}
+
+func TestProductEnumsStrictCatalogue(t *testing.T) {
+	testServer := httptest.NewServer(newFakeStore())
+	defer testServer.Close()
+
+	catalogue, err := epcc.ParseCatalogue([]byte("products:\n  - sku: FRG\n    status: archived\n"))
+	assert.Equal(t, nil, err)
+
+	client := epcc.NewClient(epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+		StrictEnums:       true,
+	})
+	_, err = epcc.PlanCatalogue(client, catalogue, epcc.ApplyOptions{})
+	assert.Equal(t, errors.New(`error invalid product status "archived"`), err)
+}
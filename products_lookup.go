@@ -0,0 +1,133 @@
+package epcc
+
+import "sync"
+
+// skuBatchSize is how many SKUs are resolved by each request, it keeps the
+// filter of each request short enough for the length limit of a URL.
+const skuBatchSize = 25
+
+// GetBySKU fetches a single product by its SKU.
+// It returns a *NotFoundError when no product has the SKU.
+func (products) GetBySKU(client *Client, sku string) (*ProductData, error) {
+	return getProductBy(client, "sku", sku, func(p Product) string { return p.SKU })
+}
+
+// GetBySlug fetches a single product by its slug.
+// It returns a *NotFoundError when no product has the slug.
+func (products) GetBySlug(client *Client, slug string) (*ProductData, error) {
+	return getProductBy(client, "slug", slug, func(p Product) string { return p.Slug })
+}
+
+func getProductBy(client *Client, key string, value string, field func(Product) string) (*ProductData, error) {
+	products, err := Products.GetAllFiltered(client, Filter{}.Eq(key, value))
+	if err != nil {
+		return nil, err
+	}
+
+	// The server may match case insensitively, so only an exact match is returned.
+	for _, product := range products.Data {
+		if field(product) == value {
+			return &ProductData{Data: product}, nil
+		}
+	}
+
+	return nil, &NotFoundError{Resource: "product", Key: key, Value: value}
+}
+
+// SKUResolver resolves product SKUs to product IDs, caching every ID it finds.
+// It is safe for concurrent use.
+type SKUResolver struct {
+	client *Client
+	mu     sync.Mutex
+	ids    map[string]string
+}
+
+// NewSKUResolver creates a resolver which makes requests with client.
+func NewSKUResolver(client *Client) *SKUResolver {
+	return &SKUResolver{
+		client: client,
+		ids:    map[string]string{},
+	}
+}
+
+// Resolve returns the product ID of each SKU which exists, keyed by SKU.
+// SKUs which are not cached are fetched with one request for each batch of SKUs.
+// SKUs which do not exist are left out of the result and are not cached.
+func (r *SKUResolver) Resolve(skus ...string) (map[string]string, error) {
+	resolved := map[string]string{}
+	var missing []string
+
+	r.mu.Lock()
+	seen := map[string]bool{}
+	for _, sku := range skus {
+		if seen[sku] {
+			continue
+		}
+		seen[sku] = true
+
+		if id, ok := r.ids[sku]; ok {
+			resolved[sku] = id
+		} else {
+			missing = append(missing, sku)
+		}
+	}
+	r.mu.Unlock()
+
+	for start := 0; start < len(missing); start += skuBatchSize {
+		end := start + skuBatchSize
+		if end > len(missing) {
+			end = len(missing)
+		}
+		batch := missing[start:end]
+
+		products, err := Products.GetAllFiltered(r.client, Filter{}.In("sku", batch...))
+		if err != nil {
+			return nil, err
+		}
+
+		wanted := map[string]bool{}
+		for _, sku := range batch {
+			wanted[sku] = true
+		}
+
+		r.mu.Lock()
+		for _, product := range products.Data {
+			if wanted[product.SKU] {
+				r.ids[product.SKU] = product.ID
+				resolved[product.SKU] = product.ID
+			}
+		}
+		r.mu.Unlock()
+	}
+
+	return resolved, nil
+}
+
+// ResolveOne returns the product ID of a single SKU.
+// It returns a *NotFoundError when no product has the SKU.
+func (r *SKUResolver) ResolveOne(sku string) (string, error) {
+	resolved, err := r.Resolve(sku)
+	if err != nil {
+		return "", err
+	}
+
+	id, ok := resolved[sku]
+	if !ok {
+		return "", &NotFoundError{Resource: "product", Key: "sku", Value: sku}
+	}
+	return id, nil
+}
+
+// Add caches the product ID of a SKU, for example after the product is created.
+func (r *SKUResolver) Add(sku string, productID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ids[sku] = productID
+}
+
+// Forget removes a SKU from the cache, for example after the product is deleted.
+func (r *SKUResolver) Forget(sku string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.ids, sku)
+}
@@ -0,0 +1,168 @@
+package epcc_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeProductsLookup serves products filtered by eq(sku), eq(slug) and in(sku) and counts requests.
+type fakeProductsLookup struct {
+	products []epcc.Product
+	requests int32
+}
+
+func (f *fakeProductsLookup) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	atomic.AddInt32(&f.requests, 1)
+
+	filter := req.URL.Query().Get("filter")
+	if req.URL.Path != "/v2/products" || req.Method != "GET" || filter == "" {
+		rw.WriteHeader(500)
+		return
+	}
+
+	operator := filter[:strings.Index(filter, "(")]
+	args := strings.Split(strings.TrimSuffix(filter[len(operator)+1:], ")"), ",")
+
+	matched := []epcc.Product{}
+	for _, product := range f.products {
+		value := product.SKU
+		if args[0] == "slug" {
+			value = product.Slug
+		}
+		for _, arg := range args[1:] {
+			// The server matches case insensitively.
+			if strings.EqualFold(value, arg) {
+				matched = append(matched, product)
+			}
+		}
+	}
+
+	rw.WriteHeader(200)
+	json.NewEncoder(rw).Encode(epcc.ProductsData{Data: matched})
+}
+
+func newFakeProductsLookup(count int) *fakeProductsLookup {
+	fake := &fakeProductsLookup{}
+	for i := 0; i < count; i++ {
+		fake.products = append(fake.products, epcc.Product{
+			Type: "product",
+			ID:   fmt.Sprintf("id-%d", i),
+			SKU:  fmt.Sprintf("SKU-%d", i),
+			Slug: fmt.Sprintf("slug-%d", i),
+		})
+	}
+	return fake
+}
+
+func TestProductsGetBySKUAndSlug(t *testing.T) {
+	fake := newFakeProductsLookup(3)
+	fake.products = append(fake.products, epcc.Product{Type: "product", ID: "lower", SKU: "sku-1"})
+
+	// Create a new client and configure it to use test server instead of the real API endpoint.
+	testServer := httptest.NewServer(fake)
+	options := epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	}
+	client := epcc.NewClient(options)
+
+	tests := []struct {
+		get   func(*epcc.Client, string) (*epcc.ProductData, error)
+		value string
+		id    string
+		err   error
+	}{
+		{epcc.Products.GetBySKU, "SKU-1", "id-1", nil},
+		{epcc.Products.GetBySKU, "sku-1", "lower", nil},
+		{epcc.Products.GetBySKU, "SKU-9", "", &epcc.NotFoundError{Resource: "product", Key: "sku", Value: "SKU-9"}},
+		{epcc.Products.GetBySlug, "slug-2", "id-2", nil},
+		{epcc.Products.GetBySlug, "SLUG-2", "", &epcc.NotFoundError{Resource: "product", Key: "slug", Value: "SLUG-2"}},
+	}
+
+	for _, test := range tests {
+		productData, err := test.get(client, test.value)
+		assert.Equal(t, test.err, err)
+		if err == nil {
+			assert.Equal(t, test.id, productData.Data.ID)
+		}
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{&epcc.NotFoundError{Resource: "product", Key: "sku", Value: "FRG"}, true},
+		{fmt.Errorf("lookup: %w", &epcc.NotFoundError{}), true},
+		{&epcc.APIError{StatusCode: 404}, true},
+		{&epcc.APIError{StatusCode: 400}, false},
+		{errors.New("status code 404 is not ok"), false},
+		{nil, false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, epcc.IsNotFound(test.err))
+	}
+
+	err := &epcc.NotFoundError{Resource: "product", Key: "sku", Value: "FRG"}
+	assert.Equal(t, `error product with sku "FRG" not found`, err.Error())
+}
+
+func TestSKUResolver(t *testing.T) {
+	fake := newFakeProductsLookup(60)
+
+	// Create a new client and configure it to use test server instead of the real API endpoint.
+	testServer := httptest.NewServer(fake)
+	options := epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	}
+	client := epcc.NewClient(options)
+	resolver := epcc.NewSKUResolver(client)
+
+	var skus []string
+	for i := 0; i < 60; i++ {
+		skus = append(skus, fmt.Sprintf("SKU-%d", i))
+	}
+	skus = append(skus, "SKU-1", "MISSING")
+
+	// 61 distinct SKUs are resolved in batches of 25.
+	resolved, err := resolver.Resolve(skus...)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 60, len(resolved))
+	assert.Equal(t, "id-42", resolved["SKU-42"])
+	assert.Equal(t, int32(3), atomic.LoadInt32(&fake.requests))
+
+	// Cached SKUs are not requested again, missing SKUs are.
+	id, err := resolver.ResolveOne("SKU-42")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "id-42", id)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&fake.requests))
+
+	_, err = resolver.ResolveOne("MISSING")
+	assert.Equal(t, &epcc.NotFoundError{Resource: "product", Key: "sku", Value: "MISSING"}, err)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&fake.requests))
+
+	resolver.Add("NEW", "new-id")
+	id, err = resolver.ResolveOne("NEW")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "new-id", id)
+
+	resolver.Forget("NEW")
+	_, err = resolver.ResolveOne("NEW")
+	assert.Equal(t, true, epcc.IsNotFound(err))
+	assert.Equal(t, int32(5), atomic.LoadInt32(&fake.requests))
+}
@@ -1,5 +1,7 @@
 package epcc
 
+import "encoding/json"
+
 // ProductData contains the data for a single products
 type ProductData struct {
 	Data Product `json:"data"`
@@ -19,13 +21,65 @@ type Product struct {
 	SKU           string                 `json:"sku"`
 	Description   string                 `json:"description"`
 	ManageStock   bool                   `json:"manage_stock"`
-	Status        string                 `json:"status"`
-	CommodityType string                 `json:"commodity_type"`
+	Status        ProductStatus          `json:"status"`
+	CommodityType CommodityType          `json:"commodity_type"`
 	Price         []ProductPrice         `json:"price"`
 	Meta          ProductMeta            `json:"meta,omitempty"`
 	Weight        *ProductWeight         `json:"weight,omitempty"`
 	Relationships ProductRelationships   `json:"relationships,omitempty"`
 	Dimensions    map[string]Measurement `json:"dimensions,omitempty"`
+	attributes    map[string]json.RawMessage
+}
+
+// UnmarshalJSON decodes a product, keeping any attributes which are not fields of Product.
+func (p *Product) UnmarshalJSON(data []byte) error {
+	type product Product
+	var decoded product
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	attributes, err := unknownAttributes(data, decoded)
+	if err != nil {
+		return err
+	}
+
+	*p = Product(decoded)
+	p.attributes = attributes
+	return nil
+}
+
+// MarshalJSON encodes a product, including any attributes which are not fields of Product.
+func (p Product) MarshalJSON() ([]byte, error) {
+	type product Product
+	data, err := json.Marshal(product(p))
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeAttributes(data, p.attributes)
+}
+
+// Attributes returns a copy of the attributes of the product which are not fields of Product,
+// such as custom flow fields and attributes added to the API after this client.
+func (p Product) Attributes() map[string]json.RawMessage {
+	return copyAttributes(p.attributes)
+}
+
+// Attribute decodes an attribute which is not a field of Product into v and reports whether it exists.
+func (p Product) Attribute(name string, v interface{}) (bool, error) {
+	return getAttribute(p.attributes, name, v)
+}
+
+// SetAttribute sets an attribute which is not a field of Product, it is sent when the product is encoded.
+func (p *Product) SetAttribute(name string, v interface{}) error {
+	attributes, err := setAttribute(copyAttributes(p.attributes), *p, name, v)
+	if err != nil {
+		return err
+	}
+
+	p.attributes = attributes
+	return nil
 }
 
 // Measurement represents a measurement
@@ -50,7 +104,7 @@ type ProductMeta struct {
 	VariationMatrix ProductVariationMatrix `json:"variation_matrix"`
 }
 
-//ProductVariationMatrix is a map of variationID's to VariationOptions and child product IDs
+// ProductVariationMatrix is a map of variationID's to VariationOptions and child product IDs
 type ProductVariationMatrix map[string]VariationOptionToChildProduct
 
 // VariationOptionToChildProduct is a map of variationOptionIDs to child productIDs
@@ -71,8 +125,8 @@ type ProductVariationOptions struct {
 
 // ProductStock is a stock object for a Products meta
 type ProductStock struct {
-	Level        int    `json:"level"`
-	Availability string `json:"availability"`
+	Level        int               `json:"level"`
+	Availability StockAvailability `json:"availability"`
 }
 
 // ProductWeight represents the weight of a product
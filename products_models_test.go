@@ -497,3 +497,44 @@ func TestMultipleProductsDataUnmarshal(t *testing.T) {
 	assert.Equal(t, nil, err)
 	assert.Equal(t, expectedProductsData, productsData)
 }
+
+func TestProductUnknownAttributesRoundTrip(t *testing.T) {
+	rawJSON := `{"data":{"id":"64e4ce0d-c8d6-4c17-a929-de111ecc5140","type":"product","name":"Origami Cat","slug":"origami-cat","sku":"origami-cat","description":"","manage_stock":false,"status":"draft","commodity_type":"physical","price":null,"colour":"green","mpn":{"code":"CAT-1"}}}`
+
+	var productData epcc.ProductData
+	err := json.Unmarshal([]byte(rawJSON), &productData)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Origami Cat", productData.Data.Name)
+	assert.Equal(t, 2, len(productData.Data.Attributes()))
+
+	var colour string
+	found, err := productData.Data.Attribute("colour", &colour)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, found)
+	assert.Equal(t, "green", colour)
+
+	found, err = productData.Data.Attribute("size", &colour)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, found)
+
+	// Changing a field keeps unknown attributes when the product is encoded again.
+	productData.Data.Name = "Origami Tiger"
+	encoded, err := json.Marshal(productData)
+	assert.Equal(t, nil, err)
+
+	var roundTrip map[string]map[string]interface{}
+	err = json.Unmarshal(encoded, &roundTrip)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Origami Tiger", roundTrip["data"]["name"])
+	assert.Equal(t, "green", roundTrip["data"]["colour"])
+	assert.Equal(t, map[string]interface{}{"code": "CAT-1"}, roundTrip["data"]["mpn"])
+
+	// Attributes returns a copy.
+	productData.Data.Attributes()["colour"] = json.RawMessage(`"red"`)
+	found, err = productData.Data.Attribute("colour", &colour)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "green", colour)
+
+	err = productData.Data.SetAttribute("name", "Origami Tiger")
+	assert.Equal(t, "error name is not an unknown attribute", err.Error())
+}
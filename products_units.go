@@ -0,0 +1,185 @@
+package epcc
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Weight units accepted by NewProductWeight.
+const (
+	UnitGrams     = "g"
+	UnitKilograms = "kg"
+	UnitPounds    = "lb"
+	UnitOunces    = "oz"
+)
+
+// Length units recognised in the Measurement of a product's Dimensions.
+const (
+	UnitMillimetres = "mm"
+	UnitCentimetres = "cm"
+	UnitMetres      = "m"
+	UnitInches      = "in"
+	UnitFeet        = "ft"
+)
+
+// UnitSystem is a system of measurement dimensions can be converted to.
+type UnitSystem int
+
+// Unit systems, metric dimensions are in centimetres and imperial dimensions are in inches.
+const (
+	Metric UnitSystem = iota
+	Imperial
+)
+
+// DefaultVolumetricDivisor is the number of cubic centimetres per kilogram used by most couriers.
+const DefaultVolumetricDivisor = 5000
+
+// gramsPerUnit is the number of grams in each weight unit.
+var gramsPerUnit = map[string]float64{
+	UnitGrams:     1,
+	UnitKilograms: 1000,
+	UnitPounds:    453.59237,
+	UnitOunces:    28.349523125,
+}
+
+// centimetresPerUnit is the number of centimetres in each length unit.
+var centimetresPerUnit = map[string]float64{
+	UnitMillimetres: 0.1,
+	UnitCentimetres: 1,
+	UnitMetres:      100,
+	UnitInches:      2.54,
+	UnitFeet:        30.48,
+}
+
+// weightPrecision is the number of decimal places kept for kilograms, pounds and ounces.
+const weightPrecision = 5
+
+// NewProductWeight creates a ProductWeight from a value in a single unit, deriving every other unit from it.
+// Grams are rounded to a whole number and the other units to 5 decimal places.
+func NewProductWeight(value float64, unit string) (*ProductWeight, error) {
+	perUnit, ok := gramsPerUnit[strings.ToLower(unit)]
+	if !ok {
+		return nil, fmt.Errorf("error unrecognised weight unit %q", unit)
+	}
+	if value < 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, fmt.Errorf("error invalid weight %v", value)
+	}
+
+	grams := value * perUnit
+
+	return &ProductWeight{
+		Grams:     int(math.Round(grams)),
+		Kilograms: roundTo(grams/gramsPerUnit[UnitKilograms], weightPrecision),
+		Pounds:    roundTo(grams/gramsPerUnit[UnitPounds], weightPrecision),
+		Ounces:    roundTo(grams/gramsPerUnit[UnitOunces], weightPrecision),
+	}, nil
+}
+
+// Validate checks that the unit of a measurement is a recognised length unit and its value is not negative.
+func (m Measurement) Validate() error {
+	if _, ok := centimetresPerUnit[strings.ToLower(m.Unit)]; !ok {
+		return fmt.Errorf("error unrecognised unit %q for measurement %s", m.Unit, m.Measurement)
+	}
+	if m.Value < 0 || math.IsNaN(m.Value) || math.IsInf(m.Value, 0) {
+		return fmt.Errorf("error invalid value %v for measurement %s", m.Value, m.Measurement)
+	}
+	return nil
+}
+
+// Convert returns the measurement in another length unit.
+func (m Measurement) Convert(unit string) (Measurement, error) {
+	if err := m.Validate(); err != nil {
+		return Measurement{}, err
+	}
+
+	perUnit, ok := centimetresPerUnit[strings.ToLower(unit)]
+	if !ok {
+		return Measurement{}, fmt.Errorf("error unrecognised unit %q", unit)
+	}
+
+	centimetres := m.Value * centimetresPerUnit[strings.ToLower(m.Unit)]
+
+	return Measurement{
+		Measurement: m.Measurement,
+		Unit:        strings.ToLower(unit),
+		Value:       roundTo(centimetres/perUnit, weightPrecision),
+	}, nil
+}
+
+// ValidateDimensions checks every measurement of a product's dimensions.
+func ValidateDimensions(dimensions map[string]Measurement) error {
+	for name, measurement := range dimensions {
+		if measurement.Measurement == "" {
+			measurement.Measurement = name
+		}
+		if err := measurement.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConvertDimensions returns a copy of a product's dimensions with every measurement in
+// centimetres for Metric or inches for Imperial.
+func ConvertDimensions(dimensions map[string]Measurement, system UnitSystem) (map[string]Measurement, error) {
+	unit := UnitCentimetres
+	if system == Imperial {
+		unit = UnitInches
+	}
+
+	converted := make(map[string]Measurement, len(dimensions))
+	for name, measurement := range dimensions {
+		c, err := measurement.Convert(unit)
+		if err != nil {
+			return nil, err
+		}
+		converted[name] = c
+	}
+	return converted, nil
+}
+
+// VolumetricWeight calculates the volumetric weight of a product from the "length", "width" and "height"
+// of its dimensions. divisor is the number of cubic centimetres per kilogram, usually DefaultVolumetricDivisor.
+func VolumetricWeight(dimensions map[string]Measurement, divisor float64) (*ProductWeight, error) {
+	if divisor <= 0 {
+		return nil, fmt.Errorf("error invalid volumetric divisor %v", divisor)
+	}
+
+	volume := 1.0
+	for _, name := range []string{"length", "width", "height"} {
+		measurement, ok := dimensions[name]
+		if !ok {
+			return nil, fmt.Errorf("error dimensions have no %s", name)
+		}
+
+		centimetres, err := measurement.Convert(UnitCentimetres)
+		if err != nil {
+			return nil, err
+		}
+		volume *= centimetres.Value
+	}
+
+	return NewProductWeight(volume/divisor, UnitKilograms)
+}
+
+// ChargeableWeight returns the greater of a product's weight and its volumetric weight,
+// which is the weight most couriers charge for.
+func ChargeableWeight(product Product, divisor float64) (*ProductWeight, error) {
+	volumetric, err := VolumetricWeight(product.Dimensions, divisor)
+	if err != nil {
+		return nil, err
+	}
+
+	if product.Weight != nil && product.Weight.Kilograms > volumetric.Kilograms {
+		weight := *product.Weight
+		return &weight, nil
+	}
+	return volumetric, nil
+}
+
+// roundTo rounds a value to a number of decimal places.
+func roundTo(value float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(value*scale) / scale
+}
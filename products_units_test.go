@@ -0,0 +1,112 @@
+package epcc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProductWeight(t *testing.T) {
+	tests := []struct {
+		value  float64
+		unit   string
+		weight *epcc.ProductWeight
+		err    error
+	}{
+		{5, "g", &epcc.ProductWeight{Grams: 5, Kilograms: 0.005, Pounds: 0.01102, Ounces: 0.17637}, nil},
+		{0.005, "KG", &epcc.ProductWeight{Grams: 5, Kilograms: 0.005, Pounds: 0.01102, Ounces: 0.17637}, nil},
+		{1, "lb", &epcc.ProductWeight{Grams: 454, Kilograms: 0.45359, Pounds: 1, Ounces: 16}, nil},
+		{16, "oz", &epcc.ProductWeight{Grams: 454, Kilograms: 0.45359, Pounds: 1, Ounces: 16}, nil},
+		{0, "g", &epcc.ProductWeight{}, nil},
+		{1, "stone", nil, errors.New(`error unrecognised weight unit "stone"`)},
+		{-1, "g", nil, errors.New("error invalid weight -1")},
+	}
+
+	for _, test := range tests {
+		weight, err := epcc.NewProductWeight(test.value, test.unit)
+		assert.Equal(t, test.weight, weight)
+		assert.Equal(t, test.err, err)
+	}
+}
+
+func TestMeasurementConvert(t *testing.T) {
+	tests := []struct {
+		measurement epcc.Measurement
+		unit        string
+		converted   epcc.Measurement
+		err         error
+	}{
+		{epcc.Measurement{Measurement: "width", Unit: "in", Value: 10}, "cm", epcc.Measurement{Measurement: "width", Unit: "cm", Value: 25.4}, nil},
+		{epcc.Measurement{Measurement: "width", Unit: "cm", Value: 25.4}, "in", epcc.Measurement{Measurement: "width", Unit: "in", Value: 10}, nil},
+		{epcc.Measurement{Measurement: "height", Unit: "ft", Value: 1}, "mm", epcc.Measurement{Measurement: "height", Unit: "mm", Value: 304.8}, nil},
+		{epcc.Measurement{Measurement: "height", Unit: "m", Value: 1.5}, "cm", epcc.Measurement{Measurement: "height", Unit: "cm", Value: 150}, nil},
+		{epcc.Measurement{Measurement: "height", Unit: "cubits", Value: 1}, "cm", epcc.Measurement{}, errors.New(`error unrecognised unit "cubits" for measurement height`)},
+		{epcc.Measurement{Measurement: "height", Unit: "cm", Value: -1}, "cm", epcc.Measurement{}, errors.New("error invalid value -1 for measurement height")},
+		{epcc.Measurement{Measurement: "height", Unit: "cm", Value: 1}, "league", epcc.Measurement{}, errors.New(`error unrecognised unit "league"`)},
+	}
+
+	for _, test := range tests {
+		converted, err := test.measurement.Convert(test.unit)
+		assert.Equal(t, test.converted, converted)
+		assert.Equal(t, test.err, err)
+	}
+}
+
+func TestConvertDimensions(t *testing.T) {
+	dimensions := map[string]epcc.Measurement{
+		"length": {Measurement: "length", Unit: "mm", Value: 300},
+		"width":  {Measurement: "width", Unit: "in", Value: 8},
+	}
+
+	assert.Equal(t, nil, epcc.ValidateDimensions(dimensions))
+
+	metric, err := epcc.ConvertDimensions(dimensions, epcc.Metric)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]epcc.Measurement{
+		"length": {Measurement: "length", Unit: "cm", Value: 30},
+		"width":  {Measurement: "width", Unit: "cm", Value: 20.32},
+	}, metric)
+
+	imperial, err := epcc.ConvertDimensions(dimensions, epcc.Imperial)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]epcc.Measurement{
+		"length": {Measurement: "length", Unit: "in", Value: 11.81102},
+		"width":  {Measurement: "width", Unit: "in", Value: 8},
+	}, imperial)
+
+	dimensions["depth"] = epcc.Measurement{Unit: "furlong", Value: 1}
+	err = epcc.ValidateDimensions(dimensions)
+	assert.Equal(t, errors.New(`error unrecognised unit "furlong" for measurement depth`), err)
+}
+
+func TestVolumetricWeight(t *testing.T) {
+	dimensions := map[string]epcc.Measurement{
+		"length": {Measurement: "length", Unit: "cm", Value: 50},
+		"width":  {Measurement: "width", Unit: "cm", Value: 40},
+		"height": {Measurement: "height", Unit: "mm", Value: 250},
+	}
+
+	weight, err := epcc.VolumetricWeight(dimensions, epcc.DefaultVolumetricDivisor)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 10000, weight.Grams)
+	assert.Equal(t, 10.0, weight.Kilograms)
+
+	light := epcc.Product{Dimensions: dimensions, Weight: &epcc.ProductWeight{Grams: 2000, Kilograms: 2}}
+	chargeable, err := epcc.ChargeableWeight(light, epcc.DefaultVolumetricDivisor)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, weight, chargeable)
+
+	heavy := epcc.Product{Dimensions: dimensions, Weight: &epcc.ProductWeight{Grams: 12000, Kilograms: 12}}
+	chargeable, err = epcc.ChargeableWeight(heavy, epcc.DefaultVolumetricDivisor)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, heavy.Weight, chargeable)
+
+	delete(dimensions, "height")
+	_, err = epcc.VolumetricWeight(dimensions, epcc.DefaultVolumetricDivisor)
+	assert.Equal(t, errors.New("error dimensions have no height"), err)
+
+	_, err = epcc.VolumetricWeight(dimensions, 0)
+	assert.Equal(t, errors.New("error invalid volumetric divisor 0"), err)
+}
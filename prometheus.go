@@ -0,0 +1,183 @@
+package epcc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultDurationBuckets are the upper bounds in seconds of the request duration histogram.
+var DefaultDurationBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// PrometheusMetrics records a client's metrics in memory and serves them in the Prometheus text format:
+//
+//	epcc_requests_total{endpoint,method,status}          requests by the status of their last response, or "error"
+//	epcc_request_attempts_total{endpoint,method,status}  attempts at sending requests, including retries
+//	epcc_request_retries_total{endpoint,method}          attempts which were retried
+//	epcc_request_duration_seconds{endpoint,method}       histogram of request durations, including every retry
+//
+// It is safe for concurrent use and can be shared by many clients.
+type PrometheusMetrics struct {
+	mu        sync.Mutex
+	buckets   []float64
+	requests  map[metricLabels]uint64
+	attempts  map[metricLabels]uint64
+	retries   map[metricLabels]uint64
+	durations map[metricLabels]*histogram
+}
+
+// metricLabels are the labels of a series, status is empty for series without it.
+type metricLabels struct {
+	endpoint string
+	method   string
+	status   string
+}
+
+type histogram struct {
+	counts []uint64 // counts holds the observations at or below each bucket.
+	sum    float64
+	count  uint64
+}
+
+// NewPrometheusMetrics creates a metrics recorder which serves metrics in the Prometheus text format.
+func NewPrometheusMetrics() *PrometheusMetrics {
+	return &PrometheusMetrics{
+		buckets:   DefaultDurationBuckets,
+		requests:  map[metricLabels]uint64{},
+		attempts:  map[metricLabels]uint64{},
+		retries:   map[metricLabels]uint64{},
+		durations: map[metricLabels]*histogram{},
+	}
+}
+
+// RecordAttempt counts an attempt and whether it was retried.
+func (p *PrometheusMetrics) RecordAttempt(metrics AttemptMetrics) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.attempts[metricLabels{metrics.Endpoint, metrics.Method, statusLabel(metrics.Status)}]++
+	if metrics.Retried {
+		p.retries[metricLabels{endpoint: metrics.Endpoint, method: metrics.Method}]++
+	}
+}
+
+// RecordRequest counts a request and observes its duration.
+func (p *PrometheusMetrics) RecordRequest(metrics RequestMetrics) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.requests[metricLabels{metrics.Endpoint, metrics.Method, statusLabel(metrics.Status)}]++
+
+	labels := metricLabels{endpoint: metrics.Endpoint, method: metrics.Method}
+	h, ok := p.durations[labels]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(p.buckets))}
+		p.durations[labels] = h
+	}
+	seconds := metrics.Duration.Seconds()
+	for i, bound := range p.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format, so they can be scraped.
+func (p *PrometheusMetrics) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	rw.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	p.Write(rw)
+}
+
+// Write writes the metrics in the Prometheus text format. Series are sorted by their labels.
+func (p *PrometheusMetrics) Write(w io.Writer) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	b := bufio.NewWriter(w)
+	writeCounter(b, "epcc_requests_total", "Requests made by the client, by the status of their last response.", p.requests)
+	writeCounter(b, "epcc_request_attempts_total", "Attempts at sending requests, including retries.", p.attempts)
+	writeCounter(b, "epcc_request_retries_total", "Attempts which were retried because of their status.", p.retries)
+
+	fmt.Fprintln(b, "# HELP epcc_request_duration_seconds Duration of requests, including every retry.")
+	fmt.Fprintln(b, "# TYPE epcc_request_duration_seconds histogram")
+	for _, labels := range sortedLabels(p.durations) {
+		h := p.durations[labels]
+		for i, bound := range p.buckets {
+			fmt.Fprintf(b, "epcc_request_duration_seconds_bucket%s %d\n", labels.format("le", formatFloat(bound)), h.counts[i])
+		}
+		fmt.Fprintf(b, "epcc_request_duration_seconds_bucket%s %d\n", labels.format("le", "+Inf"), h.count)
+		fmt.Fprintf(b, "epcc_request_duration_seconds_sum%s %s\n", labels.format(), formatFloat(h.sum))
+		fmt.Fprintf(b, "epcc_request_duration_seconds_count%s %d\n", labels.format(), h.count)
+	}
+	return b.Flush()
+}
+
+func writeCounter(w io.Writer, name string, help string, series map[metricLabels]uint64) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s counter\n", name)
+	for _, labels := range sortedLabels(series) {
+		fmt.Fprintf(w, "%s%s %d\n", name, labels.format(), series[labels])
+	}
+}
+
+// sortedLabels returns the labels of a metric's series in order, so the output is the same each time.
+func sortedLabels(series interface{}) []metricLabels {
+	var labels []metricLabels
+	switch s := series.(type) {
+	case map[metricLabels]uint64:
+		for l := range s {
+			labels = append(labels, l)
+		}
+	case map[metricLabels]*histogram:
+		for l := range s {
+			labels = append(labels, l)
+		}
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if labels[i].endpoint != labels[j].endpoint {
+			return labels[i].endpoint < labels[j].endpoint
+		}
+		if labels[i].method != labels[j].method {
+			return labels[i].method < labels[j].method
+		}
+		return labels[i].status < labels[j].status
+	})
+	return labels
+}
+
+// format writes the labels in alphabetical order followed by any extra name and value pairs.
+func (l metricLabels) format(extra ...string) string {
+	pairs := []string{"endpoint", l.endpoint, "method", l.method}
+	if l.status != "" {
+		pairs = append(pairs, "status", l.status)
+	}
+	pairs = append(pairs, extra...)
+
+	var labels []string
+	for i := 0; i < len(pairs); i += 2 {
+		labels = append(labels, pairs[i]+`="`+labelEscaper.Replace(pairs[i+1])+`"`)
+	}
+	return "{" + strings.Join(labels, ",") + "}"
+}
+
+// labelEscaper escapes label values as the Prometheus text format requires.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// statusLabel returns the status code as a label, or error when no response was received.
+func statusLabel(status int) string {
+	if status == 0 {
+		return "error"
+	}
+	return strconv.Itoa(status)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
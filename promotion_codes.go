@@ -0,0 +1,176 @@
+package epcc
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"errors"
+	"math/rand"
+)
+
+// PromotionCodeGenerator generates unique promotion codes from a pattern.
+//
+// In a Pattern, '#' is replaced by a random digit, '?' by a random upper case letter
+// and '*' by either. Every other character is copied into the code as it is,
+// so "SUMMER-####-??" produces codes such as "SUMMER-4821-QX".
+type PromotionCodeGenerator struct {
+	Pattern   string     // Pattern is the template each code is generated from.
+	Count     int        // Count is how many new codes to generate.
+	Uses      int        // Uses is how many times each code can be used, 0 is unlimited.
+	BatchSize int        // BatchSize is how many codes are sent per request, defaults to 100.
+	Rand      *rand.Rand // Rand overrides the source of randomness so tests can generate known codes, leave it nil otherwise.
+}
+
+// PromotionCodeResult reports the outcome of generating promotion codes.
+type PromotionCodeResult struct {
+	Created    []string               // Created are the codes successfully added to the promotion.
+	Collisions []string               // Collisions are generated codes that were discarded because they already existed.
+	Failures   []PromotionCodeFailure // Failures are batches of codes the API did not accept.
+}
+
+// PromotionCodeFailure is a batch of codes which could not be created.
+type PromotionCodeFailure struct {
+	Codes []string
+	Err   error
+}
+
+const (
+	codeDigits  = "0123456789"
+	codeLetters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+)
+
+// GenerateCodes generates unique codes for a promotion and submits them in batches.
+// Codes already on the promotion are never generated again. A failed batch is recorded
+// in the result and the remaining batches are still submitted.
+func (promotions) GenerateCodes(client *Client, promotionID string, generator PromotionCodeGenerator) (*PromotionCodeResult, error) {
+	if generator.Count <= 0 {
+		return nil, errors.New("error count must be greater than zero")
+	}
+
+	existing, err := Promotions.GetCodes(client, promotionID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(existing.Data)+generator.Count)
+	for _, code := range existing.Data {
+		seen[code.Code] = true
+	}
+
+	codes, collisions, err := generator.generate(seen)
+	if err != nil {
+		return nil, err
+	}
+
+	batchSize := generator.BatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	result := PromotionCodeResult{
+		Collisions: collisions,
+	}
+
+	for start := 0; start < len(codes); start += batchSize {
+		end := start + batchSize
+		if end > len(codes) {
+			end = len(codes)
+		}
+		batch := codes[start:end]
+
+		promotionCodes := make([]PromotionCode, len(batch))
+		for i, code := range batch {
+			promotionCodes[i] = PromotionCode{Code: code, Uses: generator.Uses}
+		}
+
+		if err := Promotions.CreateCodes(client, promotionID, promotionCodes); err != nil {
+			result.Failures = append(result.Failures, PromotionCodeFailure{Codes: batch, Err: err})
+			continue
+		}
+		result.Created = append(result.Created, batch...)
+	}
+
+	return &result, nil
+}
+
+// generate returns Count codes which are not in seen, adding them to seen as it goes.
+func (g PromotionCodeGenerator) generate(seen map[string]bool) (codes []string, collisions []string, err error) {
+	if g.Pattern == "" {
+		return nil, nil, errors.New("error pattern is required")
+	}
+
+	capacity := 1.0
+	for _, char := range g.Pattern {
+		switch char {
+		case '#':
+			capacity *= float64(len(codeDigits))
+		case '?':
+			capacity *= float64(len(codeLetters))
+		case '*':
+			capacity *= float64(len(codeDigits) + len(codeLetters))
+		}
+	}
+	if capacity < float64(len(seen)+g.Count) {
+		return nil, nil, errors.New("error pattern cannot produce enough unique codes")
+	}
+
+	// Codes are generated from crypto/rand unless a test overrides it, so they cannot be predicted.
+	r := g.Rand
+	if r == nil {
+		r = rand.New(cryptoSource{})
+	}
+
+	// Give up rather than spin forever when the pattern is close to exhausted.
+	maxAttempts := g.Count * 10
+	for attempts := 0; len(codes) < g.Count; attempts++ {
+		if attempts >= maxAttempts {
+			return nil, nil, errors.New("error too many collisions generating unique codes")
+		}
+
+		code := g.code(r)
+		if seen[code] {
+			collisions = append(collisions, code)
+			continue
+		}
+
+		seen[code] = true
+		codes = append(codes, code)
+	}
+
+	return codes, collisions, nil
+}
+
+// cryptoSource is a rand.Source which reads from crypto/rand, it cannot be seeded.
+type cryptoSource struct{}
+
+func (cryptoSource) Int63() int64 {
+	return int64(cryptoSource{}.Uint64() &^ (1 << 63))
+}
+
+func (cryptoSource) Uint64() uint64 {
+	var b [8]byte
+	if _, err := cryptorand.Read(b[:]); err != nil {
+		panic("epcc: reading crypto/rand failed: " + err.Error())
+	}
+	return binary.BigEndian.Uint64(b[:])
+}
+
+func (cryptoSource) Seed(int64) {}
+
+// code generates a single code from the pattern.
+func (g PromotionCodeGenerator) code(r *rand.Rand) string {
+	code := make([]byte, 0, len(g.Pattern))
+	for _, char := range []byte(g.Pattern) {
+		switch char {
+		case '#':
+			code = append(code, codeDigits[r.Intn(len(codeDigits))])
+		case '?':
+			code = append(code, codeLetters[r.Intn(len(codeLetters))])
+		case '*':
+			chars := codeDigits + codeLetters
+			code = append(code, chars[r.Intn(len(chars))])
+		default:
+			code = append(code, char)
+		}
+	}
+	return string(code)
+}
@@ -0,0 +1,170 @@
+package epcc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Promotions is used to access the Promotions endpoints.
+var Promotions promotions
+
+type promotions struct{}
+
+// Get fetches a single promotion
+func (promotions) Get(client *Client, promotionID string) (*PromotionData, error) {
+	path := fmt.Sprintf("/v2/promotions/%s", promotionID)
+
+	body, err := client.DoRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var promotion PromotionData
+	if err := json.Unmarshal(body, &promotion); err != nil {
+		return nil, err
+	}
+
+	return &promotion, nil
+}
+
+// GetAll fetches all promotions
+func (promotions) GetAll(client *Client) (*PromotionsData, error) {
+	path := fmt.Sprintf("/v2/promotions")
+
+	body, err := client.DoRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var promotions PromotionsData
+	if err := json.Unmarshal(body, &promotions); err != nil {
+		return nil, err
+	}
+
+	return &promotions, nil
+}
+
+// Create creates a promotion
+func (promotions) Create(client *Client, promotion *Promotion) (*PromotionData, error) {
+	promotionData := PromotionData{
+		Data: promotion.withPromotionType(),
+	}
+
+	jsonPayload, err := json.Marshal(promotionData)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/v2/promotions")
+
+	body, err := client.DoRequest("POST", path, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, err
+	}
+
+	var newPromotion PromotionData
+	if err := json.Unmarshal(body, &newPromotion); err != nil {
+		return nil, err
+	}
+
+	return &newPromotion, nil
+}
+
+// Update updates a promotion.
+func (promotions) Update(client *Client, promotionID string, promotion *Promotion) (*PromotionData, error) {
+	promotionData := PromotionData{
+		Data: promotion.withPromotionType(),
+	}
+
+	jsonPayload, err := json.Marshal(promotionData)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/v2/promotions/%s", promotionID)
+
+	body, err := client.DoRequest("PUT", path, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedPromotion PromotionData
+	if err := json.Unmarshal(body, &updatedPromotion); err != nil {
+		return nil, err
+	}
+
+	return &updatedPromotion, nil
+}
+
+// withPromotionType returns a copy of the promotion, with its type inferred from a typed schema when it is not set.
+func (p *Promotion) withPromotionType() Promotion {
+	promotion := *p
+	if promotion.PromotionType == "" && promotion.Schema != nil {
+		promotion.PromotionType = promotion.Schema.promotionType()
+	}
+	return promotion
+}
+
+// Delete deletes a promotion.
+func (promotions) Delete(client *Client, promotionID string) error {
+	path := fmt.Sprintf("/v2/promotions/%s", promotionID)
+
+	if _, err := client.DoRequest("DELETE", path, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetCodes fetches the codes of a promotion, requesting every page of the results.
+func (promotions) GetCodes(client *Client, promotionID string) (*PromotionCodesData, error) {
+	path := fmt.Sprintf("/v2/promotions/%s/codes", promotionID)
+
+	var codes PromotionCodesData
+	err := getPages(client, path, func(body []byte) (int, error) {
+		var page PromotionCodesData
+		if err := json.Unmarshal(body, &page); err != nil {
+			return 0, err
+		}
+		codes.Data = append(codes.Data, page.Data...)
+		return len(page.Data), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &codes, nil
+}
+
+// CreateCodes adds codes to a promotion
+func (promotions) CreateCodes(client *Client, promotionID string, codes []PromotionCode) error {
+	return sendPromotionCodes(client, "POST", promotionID, codes)
+}
+
+// DeleteCodes removes codes from a promotion
+func (promotions) DeleteCodes(client *Client, promotionID string, codes []PromotionCode) error {
+	return sendPromotionCodes(client, "DELETE", promotionID, codes)
+}
+
+func sendPromotionCodes(client *Client, method string, promotionID string, codes []PromotionCode) error {
+	codesRequest := promotionCodesRequest{
+		Data: promotionCodesRequestData{
+			Type:  "promotion_codes",
+			Codes: codes,
+		},
+	}
+
+	jsonPayload, err := json.Marshal(codesRequest)
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/v2/promotions/%s/codes", promotionID)
+
+	if _, err := client.DoRequest(method, path, bytes.NewBuffer(jsonPayload)); err != nil {
+		return err
+	}
+
+	return nil
+}
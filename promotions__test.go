@@ -0,0 +1,315 @@
+package epcc_test
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func fakeHandlePromotionsGet(rw http.ResponseWriter, req *http.Request) {
+	switch {
+	case req.URL.String() == "/v2/promotions/validPromotionID" && req.Method == "GET":
+		responseJSON := `{
+			"data":{
+				"id":"0f0e0a2b-6d2f-4d8d-9e2c-6a5a0d0d1f11",
+				"type":"promotion",
+				"name":"Ten off",
+				"description":"Ten off everything",
+				"enabled":true,
+				"automatic":false,
+				"promotion_type":"fixed_discount",
+				"schema":{
+					"currencies":[{
+						"currency":"USD",
+						"amount":1000
+					}]
+				},
+				"start":"2020-09-01",
+				"end":"2020-10-01"
+			}
+		}`
+		rw.WriteHeader(200)
+		rw.Write([]byte(responseJSON))
+	default:
+		rw.WriteHeader(500)
+	}
+}
+
+func TestPromotionsGet(t *testing.T) {
+	expectedPromotionData := epcc.PromotionData{
+		Data: epcc.Promotion{
+			ID:            "0f0e0a2b-6d2f-4d8d-9e2c-6a5a0d0d1f11",
+			Type:          "promotion",
+			Name:          "Ten off",
+			Description:   "Ten off everything",
+			Enabled:       true,
+			Automatic:     false,
+			PromotionType: epcc.PromotionTypeFixedDiscount,
+			Schema: epcc.FixedDiscountSchema{
+				Currencies: []epcc.PromotionAmount{
+					{Currency: "USD", Amount: 1000},
+				},
+			},
+			Start: "2020-09-01",
+			End:   "2020-10-01",
+		},
+	}
+
+	tests := []struct {
+		promotionID   string
+		promotionData *epcc.PromotionData
+		err           error
+	}{
+		{"validPromotionID", &expectedPromotionData, nil},
+		{"invalidPromotionID", nil, errors.New("retry timeout error")},
+	}
+
+	// Create a new client and configure it to use test server instead of the real API endpoint.
+	testServer := httptest.NewServer(http.HandlerFunc(fakeHandlePromotionsGet))
+	options := epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	}
+	client := epcc.NewClient(options)
+
+	for _, test := range tests {
+		promotionData, err := epcc.Promotions.Get(client, test.promotionID)
+		assert.Equal(t, test.promotionData, promotionData)
+		assert.Equal(t, test.err, err)
+	}
+}
+
+func fakeHandlePromotionsCreate(rw http.ResponseWriter, req *http.Request) {
+	var buffer bytes.Buffer
+	_, err := buffer.ReadFrom(req.Body)
+	if err != nil {
+		rw.WriteHeader(500)
+		return
+	}
+
+	switch {
+	case req.URL.String() == "/v2/promotions" && req.Method == "POST" &&
+		strings.Contains(buffer.String(), `"promotion_type":"x_for_y"`) &&
+		strings.Contains(buffer.String(), `"schema":{"x":3,"y":2,"targets":["sku-origami-crane"]}`):
+		responseJSON := `{
+			"data":{
+				"id":"4a0b1c7e-2a9d-4c61-8b51-b1d6d6e4c9a3",
+				"type":"promotion",
+				"name":"Three for two",
+				"enabled":true,
+				"automatic":true,
+				"promotion_type":"x_for_y",
+				"schema":{
+					"x":3,
+					"y":2,
+					"targets":["sku-origami-crane"]
+				}
+			}
+		}`
+		rw.WriteHeader(201)
+		rw.Write([]byte(responseJSON))
+	default:
+		rw.WriteHeader(500)
+	}
+}
+
+func TestPromotionsCreate(t *testing.T) {
+	newPromotion := epcc.Promotion{
+		Type:      "promotion",
+		Name:      "Three for two",
+		Enabled:   true,
+		Automatic: true,
+		Schema: epcc.XForYSchema{
+			X:       3,
+			Y:       2,
+			Targets: []string{"sku-origami-crane"},
+		},
+	}
+
+	expectedPromotionData := epcc.PromotionData{
+		Data: epcc.Promotion{
+			ID:            "4a0b1c7e-2a9d-4c61-8b51-b1d6d6e4c9a3",
+			Type:          "promotion",
+			Name:          "Three for two",
+			Enabled:       true,
+			Automatic:     true,
+			PromotionType: epcc.PromotionTypeXForY,
+			Schema: epcc.XForYSchema{
+				X:       3,
+				Y:       2,
+				Targets: []string{"sku-origami-crane"},
+			},
+		},
+	}
+
+	// Create a new client and configure it to use test server instead of the real API endpoint.
+	testServer := httptest.NewServer(http.HandlerFunc(fakeHandlePromotionsCreate))
+	options := epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	}
+	client := epcc.NewClient(options)
+
+	promotionData, err := epcc.Promotions.Create(client, &newPromotion)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &expectedPromotionData, promotionData)
+	assert.Equal(t, "", newPromotion.PromotionType)
+}
+
+func TestPromotionsUpdate(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var buffer bytes.Buffer
+		buffer.ReadFrom(req.Body)
+		if req.URL.String() != "/v2/promotions/validPromotionID" || req.Method != "PUT" ||
+			!strings.Contains(buffer.String(), `"promotion_type":"x_for_y"`) {
+			rw.WriteHeader(500)
+			return
+		}
+		rw.WriteHeader(200)
+		rw.Write(buffer.Bytes())
+	}))
+	defer testServer.Close()
+	client := epcc.NewClient(epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	})
+
+	// The promotion type is inferred from the schema, as it is by Create.
+	promotion := epcc.Promotion{Type: "promotion", Name: "Three for two", Schema: epcc.XForYSchema{X: 3, Y: 2}}
+	promotionData, err := epcc.Promotions.Update(client, "validPromotionID", &promotion)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, epcc.PromotionTypeXForY, promotionData.Data.PromotionType)
+	assert.Equal(t, "", promotion.PromotionType)
+}
+
+func fakeHandlePromotionCodes(rw http.ResponseWriter, req *http.Request) {
+	var buffer bytes.Buffer
+	_, err := buffer.ReadFrom(req.Body)
+	if err != nil {
+		rw.WriteHeader(500)
+		return
+	}
+
+	switch {
+	case req.URL.String() == "/v2/promotions/validPromotionID/codes" && req.Method == "GET":
+		responseJSON := `{
+			"data":[
+				{"code":"0","uses":1},
+				{"code":"1","uses":1},
+				{"code":"2","uses":1}
+			],
+			"meta":{"page":{"limit":3,"offset":0,"current":1,"total":2}}
+		}`
+		rw.WriteHeader(200)
+		rw.Write([]byte(responseJSON))
+	case req.URL.String() == "/v2/promotions/validPromotionID/codes?page%5Boffset%5D=3" && req.Method == "GET":
+		responseJSON := `{
+			"data":[
+				{"code":"3","uses":1},
+				{"code":"4","uses":1}
+			],
+			"meta":{"page":{"limit":3,"offset":3,"current":2,"total":2}}
+		}`
+		rw.WriteHeader(200)
+		rw.Write([]byte(responseJSON))
+	case req.URL.String() == "/v2/promotions/validPromotionID/codes" && req.Method == "POST" &&
+		strings.Contains(buffer.String(), `"code":"9"`):
+		responseJSON := `{
+			"errors":[{
+				"status":422,
+				"title":"Unprocessable Entity",
+				"detail":"code 9 is reserved"
+			}]
+		}`
+		rw.WriteHeader(422)
+		rw.Write([]byte(responseJSON))
+	case req.URL.String() == "/v2/promotions/validPromotionID/codes" && req.Method == "POST" &&
+		strings.Contains(buffer.String(), `"type":"promotion_codes"`) &&
+		strings.Contains(buffer.String(), `"uses":1`):
+		rw.WriteHeader(201)
+		rw.Write([]byte(`{}`))
+	default:
+		rw.WriteHeader(500)
+	}
+}
+
+func TestPromotionsGenerateCodes(t *testing.T) {
+	// Create a new client and configure it to use test server instead of the real API endpoint.
+	testServer := httptest.NewServer(http.HandlerFunc(fakeHandlePromotionCodes))
+	options := epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	}
+	client := epcc.NewClient(options)
+
+	generator := epcc.PromotionCodeGenerator{
+		Pattern:   "#",
+		Count:     5,
+		Uses:      1,
+		BatchSize: 2,
+		Rand:      rand.New(rand.NewSource(1)),
+	}
+
+	result, err := epcc.Promotions.GenerateCodes(client, "validPromotionID", generator)
+	assert.Equal(t, nil, err)
+
+	// The existing codes are on two pages, every free code is generated exactly once
+	// and the batch containing 9 is rejected.
+	generated := append([]string{}, result.Created...)
+	for _, failure := range result.Failures {
+		assert.Equal(t, &epcc.APIError{
+			StatusCode: 422,
+			Errors: []epcc.ErrorDetail{
+				{Status: 422, Title: "Unprocessable Entity", Detail: "code 9 is reserved"},
+			},
+		}, failure.Err)
+		generated = append(generated, failure.Codes...)
+	}
+	sort.Strings(generated)
+	assert.Equal(t, []string{"5", "6", "7", "8", "9"}, generated)
+	assert.Equal(t, 1, len(result.Failures))
+	assert.Contains(t, result.Failures[0].Codes, "9")
+	assert.NotEqual(t, 0, len(result.Collisions))
+
+	generator.Count = 6
+	_, err = epcc.Promotions.GenerateCodes(client, "validPromotionID", generator)
+	assert.Equal(t, errors.New("error pattern cannot produce enough unique codes"), err)
+}
+
+func TestPromotionsGenerateCodesDefaultRand(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(fakeHandlePromotionCodes))
+	defer testServer.Close()
+	client := epcc.NewClient(epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	})
+
+	// Without Rand, codes come from crypto/rand and still follow the pattern.
+	generator := epcc.PromotionCodeGenerator{Pattern: "SUMMER-?*##", Count: 50, Uses: 1}
+	result, err := epcc.Promotions.GenerateCodes(client, "validPromotionID", generator)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 50, len(result.Created))
+
+	pattern := regexp.MustCompile(`^SUMMER-[A-Z][0-9A-Z][0-9]{2}$`)
+	unique := map[string]bool{}
+	for _, code := range result.Created {
+		assert.Regexp(t, pattern, code)
+		unique[code] = true
+	}
+	assert.Equal(t, 50, len(unique))
+}
@@ -0,0 +1,151 @@
+package epcc
+
+import "encoding/json"
+
+// Promotion types supported by the promotions endpoints.
+const (
+	PromotionTypeFixedDiscount   = "fixed_discount"
+	PromotionTypePercentDiscount = "percent_discount"
+	PromotionTypeXForY           = "x_for_y"
+)
+
+// PromotionData contains the data for a single promotion
+type PromotionData struct {
+	Data Promotion `json:"data"`
+}
+
+// PromotionsData contains the data for multiple promotions
+type PromotionsData struct {
+	Data []Promotion `json:"data"`
+}
+
+// Promotion represents a promotion
+type Promotion struct {
+	ID            string          `json:"id,omitempty"`
+	Type          string          `json:"type"`
+	Name          string          `json:"name,omitempty"`
+	Description   string          `json:"description,omitempty"`
+	Enabled       bool            `json:"enabled"`
+	Automatic     bool            `json:"automatic"`
+	PromotionType string          `json:"promotion_type,omitempty"`
+	Schema        PromotionSchema `json:"schema,omitempty"`
+	Start         string          `json:"start,omitempty"`
+	End           string          `json:"end,omitempty"`
+	Meta          PromotionMeta   `json:"meta,omitempty"`
+}
+
+// PromotionMeta contains extra data for a promotion
+type PromotionMeta struct {
+	Timestamps Timestamps `json:"timestamps,omitempty"`
+}
+
+// PromotionSchema is the type specific configuration of a promotion.
+// It is one of FixedDiscountSchema, PercentDiscountSchema, XForYSchema or RawPromotionSchema.
+type PromotionSchema interface {
+	promotionType() string
+}
+
+// FixedDiscountSchema takes a fixed amount off the cart total in each currency
+type FixedDiscountSchema struct {
+	Currencies []PromotionAmount `json:"currencies"`
+}
+
+func (FixedDiscountSchema) promotionType() string { return PromotionTypeFixedDiscount }
+
+// PromotionAmount is a discount amount in a single currency
+type PromotionAmount struct {
+	Currency string `json:"currency"`
+	Amount   int    `json:"amount"`
+}
+
+// PercentDiscountSchema takes a percentage off the cart total
+type PercentDiscountSchema struct {
+	Percent float64 `json:"percent"`
+}
+
+func (PercentDiscountSchema) promotionType() string { return PromotionTypePercentDiscount }
+
+// XForYSchema is a buy X get Y promotion, for example 3 for the price of 2
+type XForYSchema struct {
+	X       int      `json:"x"`
+	Y       int      `json:"y"`
+	Targets []string `json:"targets,omitempty"`
+}
+
+func (XForYSchema) promotionType() string { return PromotionTypeXForY }
+
+// RawPromotionSchema holds the schema of a promotion type without a typed schema
+type RawPromotionSchema struct {
+	json.RawMessage
+}
+
+func (RawPromotionSchema) promotionType() string { return "" }
+
+// UnmarshalJSON decodes a promotion, decoding its schema into the type matching its promotion type.
+func (p *Promotion) UnmarshalJSON(data []byte) error {
+	type promotion Promotion
+	var raw struct {
+		promotion
+		Schema json.RawMessage `json:"schema,omitempty"`
+	}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*p = Promotion(raw.promotion)
+	p.Schema = nil
+
+	if len(raw.Schema) == 0 || string(raw.Schema) == "null" {
+		return nil
+	}
+
+	var schema PromotionSchema
+	switch raw.PromotionType {
+	case PromotionTypeFixedDiscount:
+		var s FixedDiscountSchema
+		if err := json.Unmarshal(raw.Schema, &s); err != nil {
+			return err
+		}
+		schema = s
+	case PromotionTypePercentDiscount:
+		var s PercentDiscountSchema
+		if err := json.Unmarshal(raw.Schema, &s); err != nil {
+			return err
+		}
+		schema = s
+	case PromotionTypeXForY:
+		var s XForYSchema
+		if err := json.Unmarshal(raw.Schema, &s); err != nil {
+			return err
+		}
+		schema = s
+	default:
+		schema = RawPromotionSchema{raw.Schema}
+	}
+
+	p.Schema = schema
+	return nil
+}
+
+// PromotionCodesData contains the codes for a promotion
+type PromotionCodesData struct {
+	Data []PromotionCode `json:"data"`
+}
+
+// PromotionCode is a code which can be used to apply a promotion
+type PromotionCode struct {
+	ID   string `json:"id,omitempty"`
+	Code string `json:"code"`
+	Uses int    `json:"uses,omitempty"`
+}
+
+// promotionCodesRequest is the payload used to create or delete promotion codes
+type promotionCodesRequest struct {
+	Data promotionCodesRequestData `json:"data"`
+}
+
+type promotionCodesRequestData struct {
+	Type  string          `json:"type"`
+	Codes []PromotionCode `json:"codes"`
+}
@@ -0,0 +1,105 @@
+package epcc_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromotionsDataUnmarshal(t *testing.T) {
+	rawJSON := `{
+		"data":[{
+			"type":"promotion",
+			"name":"Ten percent off",
+			"enabled":true,
+			"automatic":false,
+			"promotion_type":"percent_discount",
+			"schema":{
+				"percent":10
+			}
+		},{
+			"type":"promotion",
+			"name":"Three for two",
+			"enabled":true,
+			"automatic":true,
+			"promotion_type":"x_for_y",
+			"schema":{
+				"x":3,
+				"y":2
+			}
+		},{
+			"type":"promotion",
+			"name":"Free gift",
+			"enabled":false,
+			"automatic":false,
+			"promotion_type":"free_gift",
+			"schema":{"targets":["sku-gift"]}
+		},{
+			"type":"promotion",
+			"name":"No schema",
+			"enabled":false,
+			"automatic":false,
+			"promotion_type":"fixed_discount"
+		}]
+	}`
+
+	expectedPromotionsData := epcc.PromotionsData{
+		Data: []epcc.Promotion{
+			{
+				Type:          "promotion",
+				Name:          "Ten percent off",
+				Enabled:       true,
+				PromotionType: epcc.PromotionTypePercentDiscount,
+				Schema:        epcc.PercentDiscountSchema{Percent: 10},
+			},
+			{
+				Type:          "promotion",
+				Name:          "Three for two",
+				Enabled:       true,
+				Automatic:     true,
+				PromotionType: epcc.PromotionTypeXForY,
+				Schema:        epcc.XForYSchema{X: 3, Y: 2},
+			},
+			{
+				Type:          "promotion",
+				Name:          "Free gift",
+				PromotionType: "free_gift",
+				Schema:        epcc.RawPromotionSchema{RawMessage: json.RawMessage(`{"targets":["sku-gift"]}`)},
+			},
+			{
+				Type:          "promotion",
+				Name:          "No schema",
+				PromotionType: epcc.PromotionTypeFixedDiscount,
+			},
+		},
+	}
+
+	var promotionsData epcc.PromotionsData
+	err := json.Unmarshal([]byte(rawJSON), &promotionsData)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, expectedPromotionsData, promotionsData)
+}
+
+func TestPromotionDataMarshal(t *testing.T) {
+	promotionData := epcc.PromotionData{
+		Data: epcc.Promotion{
+			Type:          "promotion",
+			Name:          "Five off",
+			Enabled:       true,
+			PromotionType: epcc.PromotionTypeFixedDiscount,
+			Schema: epcc.FixedDiscountSchema{
+				Currencies: []epcc.PromotionAmount{
+					{Currency: "GBP", Amount: 500},
+				},
+			},
+		},
+	}
+
+	expectedJSON := `{"data":{"type":"promotion","name":"Five off","enabled":true,"automatic":false,"promotion_type":"fixed_discount","schema":{"currencies":[{"currency":"GBP","amount":500}]},"meta":{"timestamps":{}}}}`
+
+	rawJSON, err := json.Marshal(promotionData)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, expectedJSON, string(rawJSON))
+}
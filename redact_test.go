@@ -0,0 +1,30 @@
+package epcc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		field    LogField
+		expected interface{}
+	}{
+		{LogField{"path", "/v2/products"}, "/v2/products"},
+		{LogField{"status", 401}, 401},
+		{LogField{"access_token", "abc"}, Redacted},
+		{LogField{"Authorization", "Bearer abc"}, Redacted},
+		{LogField{"body", `{"access_token":"abc","token_type":"Bearer"}`}, `{"access_token":"REDACTED","token_type":"Bearer"}`},
+		{LogField{"body", `{"detail":"{\"password\": \"hunter2\"}"}`}, `{"detail":"{\"password\": \"REDACTED\"}"}`},
+		{LogField{"body", "client_id=id&client_secret=shh&grant_type=client_credentials"}, "client_id=id&client_secret=REDACTED&grant_type=client_credentials"},
+		{LogField{"error", errors.New("sent Authorization: Bearer abc.def")}, "sent Authorization: Bearer REDACTED"},
+		{LogField{"detail", "the token knownToken expired"}, "the token REDACTED expired"},
+	}
+
+	for _, test := range tests {
+		redacted := redactFields([]LogField{test.field}, "knownToken", "")
+		assert.Equal(t, test.expected, redacted[0].Value, test.field.Key)
+	}
+}
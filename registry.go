@@ -0,0 +1,142 @@
+package epcc
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// storeEnv holds the environment variables of a store, prefixed by its name.
+type storeEnv struct {
+	ClientID     string `envconfig:"EPCC_CLIENT_ID"`
+	ClientSecret string `envconfig:"EPCC_CLIENT_SECRET"`
+	BaseURL      string `envconfig:"EPCC_BASE_URL"`
+}
+
+// ClientOptionsFromEnv returns the options of a store read from environment variables with a prefix,
+// such as STAGING_EPCC_CLIENT_ID, STAGING_EPCC_CLIENT_SECRET and the optional STAGING_EPCC_BASE_URL
+// for the prefix STAGING. Other options are the defaults.
+func ClientOptionsFromEnv(prefix string) (ClientOptions, error) {
+	env := storeEnv{BaseURL: cfg.BaseURL}
+	if err := envconfig.Process(prefix, &env); err != nil {
+		return ClientOptions{}, err
+	}
+	if env.ClientID == "" || env.ClientSecret == "" {
+		return ClientOptions{}, fmt.Errorf("error client id and client secret are required, set %s_EPCC_CLIENT_ID and %s_EPCC_CLIENT_SECRET", prefix, prefix)
+	}
+
+	return ClientOptions{
+		BaseURL:           env.BaseURL,
+		ClientTimeout:     cfg.ClientTimeout,
+		RetryLimitTimeout: cfg.RetryLimitTimeout,
+		ClientID:          env.ClientID,
+		ClientSecret:      env.ClientSecret,
+	}, nil
+}
+
+// Registry holds a client for each of many stores by name. It is safe for concurrent use.
+type Registry struct {
+	mu     sync.RWMutex
+	stores map[string]*store
+}
+
+type store struct {
+	client        *Client
+	mu            sync.Mutex // mu is held while the client authenticates.
+	authenticated bool
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{stores: map[string]*store{}}
+}
+
+// Add creates a client for a store with its own options, it is authenticated when it is first used.
+func (r *Registry) Add(name string, options ClientOptions) (*Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.stores[name]; ok {
+		return nil, fmt.Errorf("error store %q is already registered", name)
+	}
+
+	client := NewClient(options)
+	r.stores[name] = &store{client: client}
+	return client, nil
+}
+
+// Remove removes a store from the registry.
+func (r *Registry) Remove(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.stores, name)
+}
+
+// Names returns the names of the stores in alphabetical order.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.stores))
+	for name := range r.stores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Client returns the client of a store, authenticating it the first time. After that the client
+// authenticates again by itself when its token expires or is rejected.
+// It returns a *NotFoundError when there is no store with the name.
+func (r *Registry) Client(name string) (*Client, error) {
+	r.mu.RLock()
+	s, ok := r.stores[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, &NotFoundError{Resource: "store", Key: "name", Value: name}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.authenticated {
+		if err := s.client.Authenticate(); err != nil {
+			return nil, fmt.Errorf("error authenticating store %q: %w", name, err)
+		}
+		s.authenticated = true
+	}
+	return s.client, nil
+}
+
+// Each calls fn with the client of every store at once, and returns the errors keyed by store name.
+// A store which fails to authenticate is reported without calling fn. It returns nil when there are no errors.
+func (r *Registry) Each(fn func(name string, client *Client) error) map[string]error {
+	names := r.Names()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var errs map[string]error
+	for _, name := range names {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+
+			client, err := r.Client(name)
+			if err == nil {
+				err = fn(name, client)
+			}
+			if err != nil {
+				mu.Lock()
+				if errs == nil {
+					errs = map[string]error{}
+				}
+				errs[name] = err
+				mu.Unlock()
+			}
+		}(name)
+	}
+	wg.Wait()
+
+	return errs
+}
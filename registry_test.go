@@ -0,0 +1,102 @@
+package epcc_test
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/Rosalita/go-epcc-client/epcctest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	staging := epcctest.NewServer()
+	defer staging.Close()
+	production := epcctest.NewServer()
+	defer production.Close()
+
+	staging.AddProduct(epcc.Product{Name: "Staging Crane", Slug: "crane", SKU: "CRN"})
+	production.AddProduct(epcc.Product{Name: "Production Crane", Slug: "crane", SKU: "CRN"})
+
+	registry := epcc.NewRegistry()
+	_, err := registry.Add("staging", staging.ClientOptions())
+	assert.Equal(t, nil, err)
+	_, err = registry.Add("production", production.ClientOptions())
+	assert.Equal(t, nil, err)
+
+	_, err = registry.Add("staging", staging.ClientOptions())
+	assert.Equal(t, errors.New(`error store "staging" is already registered`), err)
+	assert.Equal(t, []string{"production", "staging"}, registry.Names())
+
+	// Each store's client uses its own credentials and token.
+	var mu sync.Mutex
+	names := map[string]string{}
+	errs := registry.Each(func(store string, client *epcc.Client) error {
+		product, err := client.Products().GetBySKU("CRN")
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		names[store] = product.Data.Name
+		mu.Unlock()
+		return nil
+	})
+	assert.Equal(t, map[string]error(nil), errs)
+	assert.Equal(t, map[string]string{"staging": "Staging Crane", "production": "Production Crane"}, names)
+
+	stagingClient, err := registry.Client("staging")
+	assert.Equal(t, nil, err)
+	productionClient, err := registry.Client("production")
+	assert.Equal(t, nil, err)
+	assert.NotEqual(t, stagingClient.AccessToken(), productionClient.AccessToken())
+
+	_, err = registry.Client("development")
+	assert.Equal(t, true, epcc.IsNotFound(err))
+	assert.EqualError(t, err, `error store with name "development" not found`)
+
+	registry.Remove("production")
+	assert.Equal(t, []string{"staging"}, registry.Names())
+}
+
+func TestRegistryAuthenticationErrors(t *testing.T) {
+	server := epcctest.NewServer()
+	defer server.Close()
+
+	registry := epcc.NewRegistry()
+	options := server.ClientOptions()
+	_, err := registry.Add("good", options)
+	assert.Equal(t, nil, err)
+	options.ClientSecret = "wrong"
+	_, err = registry.Add("bad", options)
+	assert.Equal(t, nil, err)
+
+	calls := 0
+	errs := registry.Each(func(store string, client *epcc.Client) error {
+		calls++
+		return nil
+	})
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 1, len(errs))
+	assert.EqualError(t, errs["bad"], `error authenticating store "bad": error: unexpected status 401 Unauthorized`)
+}
+
+func TestClientOptionsFromEnv(t *testing.T) {
+	os.Setenv("STAGING_EPCC_CLIENT_ID", "stagingID")
+	os.Setenv("STAGING_EPCC_CLIENT_SECRET", "stagingSecret")
+	os.Setenv("STAGING_EPCC_BASE_URL", "https://staging.example.com/")
+	defer os.Unsetenv("STAGING_EPCC_CLIENT_ID")
+	defer os.Unsetenv("STAGING_EPCC_CLIENT_SECRET")
+	defer os.Unsetenv("STAGING_EPCC_BASE_URL")
+
+	options, err := epcc.ClientOptionsFromEnv("STAGING")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "stagingID", options.ClientID)
+	assert.Equal(t, "stagingSecret", options.ClientSecret)
+	assert.Equal(t, "https://staging.example.com/", options.BaseURL)
+	assert.NotEqual(t, 0, options.ClientTimeout)
+
+	_, err = epcc.ClientOptionsFromEnv("PRODUCTION")
+	assert.EqualError(t, err, "error client id and client secret are required, set PRODUCTION_EPCC_CLIENT_ID and PRODUCTION_EPCC_CLIENT_SECRET")
+}
@@ -0,0 +1,443 @@
+package epcc
+
+import (
+	"io"
+	"time"
+)
+
+//go:generate go run ./internal/mockgen -source services.go -out epccmock/mocks.go
+
+// RequestExecutor makes requests to the EPCC API. *Client implements it.
+type RequestExecutor interface {
+	DoRequest(method string, path string, payload io.Reader) ([]byte, error)
+}
+
+// AccountMembersService accesses the Account Members endpoints with a client.
+type AccountMembersService interface {
+	Get(accountMemberID string) (*AccountMemberData, error)
+	GetAll() (*AccountMembersData, error)
+}
+
+// AccountMembershipsService accesses the Account Memberships endpoints with a client.
+type AccountMembershipsService interface {
+	Get(accountID string, membershipID string) (*AccountMembershipData, error)
+	GetAll(accountID string) (*AccountMembershipsData, error)
+	Create(accountID string, accountMemberID string) (*AccountMembershipData, error)
+	Delete(accountID string, membershipID string) error
+}
+
+// AccountsService accesses the Accounts endpoints with a client.
+type AccountsService interface {
+	Get(accountID string) (*AccountData, error)
+	GetAll() (*AccountsData, error)
+	Create(account *Account) (*AccountData, error)
+	Update(accountID string, account *Account) (*AccountData, error)
+	Delete(accountID string) error
+}
+
+// CurrenciesService accesses the Currencies endpoints with a client.
+type CurrenciesService interface {
+	Get(currencyID string) (*CurrencyData, error)
+	GetAll() (*CurrenciesData, error)
+	Create(currency *Currency) (*CurrencyData, error)
+	Update(currencyID string, currency *Currency) (*CurrencyData, error)
+	Delete(currencyID string) error
+}
+
+// EntriesService accesses the Entries endpoints with a client.
+type EntriesService interface {
+	Get(flowSlug string, entryID string) (*EntryData, error)
+	GetAll(flowSlug string) (*EntriesData, error)
+	Create(flowSlug string, entry *Entry) (*EntryData, error)
+	Update(flowSlug string, entryID string, entry *Entry) (*EntryData, error)
+	Delete(flowSlug string, entryID string) error
+}
+
+// FieldsService accesses the Fields endpoints with a client.
+type FieldsService interface {
+	Get(fieldID string) (*FieldData, error)
+	GetAll(flowSlug string) (*FieldsData, error)
+	Create(field *Field) (*FieldData, error)
+	Update(fieldID string, field *Field) (*FieldData, error)
+	Delete(fieldID string) error
+}
+
+// FlowsService accesses the Flows endpoints with a client.
+type FlowsService interface {
+	Get(flowID string) (*FlowData, error)
+	GetAll() (*FlowsData, error)
+	Create(flow *Flow) (*FlowData, error)
+	Update(flowID string, flow *Flow) (*FlowData, error)
+	Delete(flowID string) error
+}
+
+// IntegrationsService accesses the Integrations endpoints with a client.
+type IntegrationsService interface {
+	Get(integrationID string) (*IntegrationData, error)
+	GetAll() (*IntegrationsData, error)
+	Create(integration *Integration) (*IntegrationData, error)
+	Update(integrationID string, integration *Integration) (*IntegrationData, error)
+	Delete(integrationID string) error
+	GetAllLogs() (*IntegrationLogsData, error)
+	GetLogs(integrationID string) (*IntegrationLogsData, error)
+	GetJobs(integrationID string) (*IntegrationJobsData, error)
+	GetJobLogs(integrationID string, jobID string) (*IntegrationLogsData, error)
+}
+
+// ProductsService accesses the Products endpoints with a client.
+type ProductsService interface {
+	Get(productID string) (*ProductData, error)
+	GetAll() (*ProductsData, error)
+	GetAllFiltered(filter Filter) (*ProductsData, error)
+	GetUpdatedSince(since time.Time) (*ProductsData, error)
+	GetBySKU(sku string) (*ProductData, error)
+	GetBySlug(slug string) (*ProductData, error)
+	Create(product *Product) (*ProductData, error)
+	Update(product *Product) (*ProductData, error)
+	Delete(productID string) error
+	GetWithExtension(productID string, ext interface{}) (*ProductData, error)
+	CreateWithExtension(product *Product, ext interface{}) (*ProductData, error)
+	UpdateWithExtension(product *Product, ext interface{}) (*ProductData, error)
+	CreateMany(products []Product, options BulkOptions) ([]BulkResult, error)
+	UpdateMany(products []Product, options BulkOptions) ([]BulkResult, error)
+	UpsertBySKU(products []Product, options BulkOptions) ([]BulkResult, error)
+}
+
+// PromotionsService accesses the Promotions endpoints with a client.
+type PromotionsService interface {
+	Get(promotionID string) (*PromotionData, error)
+	GetAll() (*PromotionsData, error)
+	Create(promotion *Promotion) (*PromotionData, error)
+	Update(promotionID string, promotion *Promotion) (*PromotionData, error)
+	Delete(promotionID string) error
+	GetCodes(promotionID string) (*PromotionCodesData, error)
+	CreateCodes(promotionID string, codes []PromotionCode) error
+	DeleteCodes(promotionID string, codes []PromotionCode) error
+	GenerateCodes(promotionID string, generator PromotionCodeGenerator) (*PromotionCodeResult, error)
+}
+
+// AccountMembers returns the Account Members service using the client.
+func (c *Client) AccountMembers() AccountMembersService {
+	return accountMembersService{c}
+}
+
+// AccountMemberships returns the Account Memberships service using the client.
+func (c *Client) AccountMemberships() AccountMembershipsService {
+	return accountMembershipsService{c}
+}
+
+// Accounts returns the Accounts service using the client.
+func (c *Client) Accounts() AccountsService {
+	return accountsService{c}
+}
+
+// Currencies returns the Currencies service using the client.
+func (c *Client) Currencies() CurrenciesService {
+	return currenciesService{c}
+}
+
+// Entries returns the Entries service using the client.
+func (c *Client) Entries() EntriesService {
+	return entriesService{c}
+}
+
+// Fields returns the Fields service using the client.
+func (c *Client) Fields() FieldsService {
+	return fieldsService{c}
+}
+
+// Flows returns the Flows service using the client.
+func (c *Client) Flows() FlowsService {
+	return flowsService{c}
+}
+
+// Integrations returns the Integrations service using the client.
+func (c *Client) Integrations() IntegrationsService {
+	return integrationsService{c}
+}
+
+// Products returns the Products service using the client.
+func (c *Client) Products() ProductsService {
+	return productsService{c}
+}
+
+// Promotions returns the Promotions service using the client.
+func (c *Client) Promotions() PromotionsService {
+	return promotionsService{c}
+}
+
+type accountMembersService struct{ client *Client }
+
+func (s accountMembersService) Get(accountMemberID string) (*AccountMemberData, error) {
+	return AccountMembers.Get(s.client, accountMemberID)
+}
+
+func (s accountMembersService) GetAll() (*AccountMembersData, error) {
+	return AccountMembers.GetAll(s.client)
+}
+
+type accountMembershipsService struct{ client *Client }
+
+func (s accountMembershipsService) Get(accountID string, membershipID string) (*AccountMembershipData, error) {
+	return AccountMemberships.Get(s.client, accountID, membershipID)
+}
+
+func (s accountMembershipsService) GetAll(accountID string) (*AccountMembershipsData, error) {
+	return AccountMemberships.GetAll(s.client, accountID)
+}
+
+func (s accountMembershipsService) Create(accountID string, accountMemberID string) (*AccountMembershipData, error) {
+	return AccountMemberships.Create(s.client, accountID, accountMemberID)
+}
+
+func (s accountMembershipsService) Delete(accountID string, membershipID string) error {
+	return AccountMemberships.Delete(s.client, accountID, membershipID)
+}
+
+type accountsService struct{ client *Client }
+
+func (s accountsService) Get(accountID string) (*AccountData, error) {
+	return Accounts.Get(s.client, accountID)
+}
+
+func (s accountsService) GetAll() (*AccountsData, error) {
+	return Accounts.GetAll(s.client)
+}
+
+func (s accountsService) Create(account *Account) (*AccountData, error) {
+	return Accounts.Create(s.client, account)
+}
+
+func (s accountsService) Update(accountID string, account *Account) (*AccountData, error) {
+	return Accounts.Update(s.client, accountID, account)
+}
+
+func (s accountsService) Delete(accountID string) error {
+	return Accounts.Delete(s.client, accountID)
+}
+
+type currenciesService struct{ client *Client }
+
+func (s currenciesService) Get(currencyID string) (*CurrencyData, error) {
+	return Currencies.Get(s.client, currencyID)
+}
+
+func (s currenciesService) GetAll() (*CurrenciesData, error) {
+	return Currencies.GetAll(s.client)
+}
+
+func (s currenciesService) Create(currency *Currency) (*CurrencyData, error) {
+	return Currencies.Create(s.client, currency)
+}
+
+func (s currenciesService) Update(currencyID string, currency *Currency) (*CurrencyData, error) {
+	return Currencies.Update(s.client, currencyID, currency)
+}
+
+func (s currenciesService) Delete(currencyID string) error {
+	return Currencies.Delete(s.client, currencyID)
+}
+
+type entriesService struct{ client *Client }
+
+func (s entriesService) Get(flowSlug string, entryID string) (*EntryData, error) {
+	return Entries.Get(s.client, flowSlug, entryID)
+}
+
+func (s entriesService) GetAll(flowSlug string) (*EntriesData, error) {
+	return Entries.GetAll(s.client, flowSlug)
+}
+
+func (s entriesService) Create(flowSlug string, entry *Entry) (*EntryData, error) {
+	return Entries.Create(s.client, flowSlug, entry)
+}
+
+func (s entriesService) Update(flowSlug string, entryID string, entry *Entry) (*EntryData, error) {
+	return Entries.Update(s.client, flowSlug, entryID, entry)
+}
+
+func (s entriesService) Delete(flowSlug string, entryID string) error {
+	return Entries.Delete(s.client, flowSlug, entryID)
+}
+
+type fieldsService struct{ client *Client }
+
+func (s fieldsService) Get(fieldID string) (*FieldData, error) {
+	return Fields.Get(s.client, fieldID)
+}
+
+func (s fieldsService) GetAll(flowSlug string) (*FieldsData, error) {
+	return Fields.GetAll(s.client, flowSlug)
+}
+
+func (s fieldsService) Create(field *Field) (*FieldData, error) {
+	return Fields.Create(s.client, field)
+}
+
+func (s fieldsService) Update(fieldID string, field *Field) (*FieldData, error) {
+	return Fields.Update(s.client, fieldID, field)
+}
+
+func (s fieldsService) Delete(fieldID string) error {
+	return Fields.Delete(s.client, fieldID)
+}
+
+type flowsService struct{ client *Client }
+
+func (s flowsService) Get(flowID string) (*FlowData, error) {
+	return Flows.Get(s.client, flowID)
+}
+
+func (s flowsService) GetAll() (*FlowsData, error) {
+	return Flows.GetAll(s.client)
+}
+
+func (s flowsService) Create(flow *Flow) (*FlowData, error) {
+	return Flows.Create(s.client, flow)
+}
+
+func (s flowsService) Update(flowID string, flow *Flow) (*FlowData, error) {
+	return Flows.Update(s.client, flowID, flow)
+}
+
+func (s flowsService) Delete(flowID string) error {
+	return Flows.Delete(s.client, flowID)
+}
+
+type integrationsService struct{ client *Client }
+
+func (s integrationsService) Get(integrationID string) (*IntegrationData, error) {
+	return Integrations.Get(s.client, integrationID)
+}
+
+func (s integrationsService) GetAll() (*IntegrationsData, error) {
+	return Integrations.GetAll(s.client)
+}
+
+func (s integrationsService) Create(integration *Integration) (*IntegrationData, error) {
+	return Integrations.Create(s.client, integration)
+}
+
+func (s integrationsService) Update(integrationID string, integration *Integration) (*IntegrationData, error) {
+	return Integrations.Update(s.client, integrationID, integration)
+}
+
+func (s integrationsService) Delete(integrationID string) error {
+	return Integrations.Delete(s.client, integrationID)
+}
+
+func (s integrationsService) GetAllLogs() (*IntegrationLogsData, error) {
+	return Integrations.GetAllLogs(s.client)
+}
+
+func (s integrationsService) GetLogs(integrationID string) (*IntegrationLogsData, error) {
+	return Integrations.GetLogs(s.client, integrationID)
+}
+
+func (s integrationsService) GetJobs(integrationID string) (*IntegrationJobsData, error) {
+	return Integrations.GetJobs(s.client, integrationID)
+}
+
+func (s integrationsService) GetJobLogs(integrationID string, jobID string) (*IntegrationLogsData, error) {
+	return Integrations.GetJobLogs(s.client, integrationID, jobID)
+}
+
+type productsService struct{ client *Client }
+
+func (s productsService) Get(productID string) (*ProductData, error) {
+	return Products.Get(s.client, productID)
+}
+
+func (s productsService) GetAll() (*ProductsData, error) {
+	return Products.GetAll(s.client)
+}
+
+func (s productsService) GetAllFiltered(filter Filter) (*ProductsData, error) {
+	return Products.GetAllFiltered(s.client, filter)
+}
+
+func (s productsService) GetUpdatedSince(since time.Time) (*ProductsData, error) {
+	return Products.GetUpdatedSince(s.client, since)
+}
+
+func (s productsService) GetBySKU(sku string) (*ProductData, error) {
+	return Products.GetBySKU(s.client, sku)
+}
+
+func (s productsService) GetBySlug(slug string) (*ProductData, error) {
+	return Products.GetBySlug(s.client, slug)
+}
+
+func (s productsService) Create(product *Product) (*ProductData, error) {
+	return Products.Create(s.client, product)
+}
+
+func (s productsService) Update(product *Product) (*ProductData, error) {
+	return Products.Update(s.client, product)
+}
+
+func (s productsService) Delete(productID string) error {
+	return Products.Delete(s.client, productID)
+}
+
+func (s productsService) GetWithExtension(productID string, ext interface{}) (*ProductData, error) {
+	return Products.GetWithExtension(s.client, productID, ext)
+}
+
+func (s productsService) CreateWithExtension(product *Product, ext interface{}) (*ProductData, error) {
+	return Products.CreateWithExtension(s.client, product, ext)
+}
+
+func (s productsService) UpdateWithExtension(product *Product, ext interface{}) (*ProductData, error) {
+	return Products.UpdateWithExtension(s.client, product, ext)
+}
+
+func (s productsService) CreateMany(products []Product, options BulkOptions) ([]BulkResult, error) {
+	return Products.CreateMany(s.client, products, options)
+}
+
+func (s productsService) UpdateMany(products []Product, options BulkOptions) ([]BulkResult, error) {
+	return Products.UpdateMany(s.client, products, options)
+}
+
+func (s productsService) UpsertBySKU(products []Product, options BulkOptions) ([]BulkResult, error) {
+	return Products.UpsertBySKU(s.client, products, options)
+}
+
+type promotionsService struct{ client *Client }
+
+func (s promotionsService) Get(promotionID string) (*PromotionData, error) {
+	return Promotions.Get(s.client, promotionID)
+}
+
+func (s promotionsService) GetAll() (*PromotionsData, error) {
+	return Promotions.GetAll(s.client)
+}
+
+func (s promotionsService) Create(promotion *Promotion) (*PromotionData, error) {
+	return Promotions.Create(s.client, promotion)
+}
+
+func (s promotionsService) Update(promotionID string, promotion *Promotion) (*PromotionData, error) {
+	return Promotions.Update(s.client, promotionID, promotion)
+}
+
+func (s promotionsService) Delete(promotionID string) error {
+	return Promotions.Delete(s.client, promotionID)
+}
+
+func (s promotionsService) GetCodes(promotionID string) (*PromotionCodesData, error) {
+	return Promotions.GetCodes(s.client, promotionID)
+}
+
+func (s promotionsService) CreateCodes(promotionID string, codes []PromotionCode) error {
+	return Promotions.CreateCodes(s.client, promotionID, codes)
+}
+
+func (s promotionsService) DeleteCodes(promotionID string, codes []PromotionCode) error {
+	return Promotions.DeleteCodes(s.client, promotionID, codes)
+}
+
+func (s promotionsService) GenerateCodes(promotionID string, generator PromotionCodeGenerator) (*PromotionCodeResult, error) {
+	return Promotions.GenerateCodes(s.client, promotionID, generator)
+}
@@ -0,0 +1,52 @@
+package epcc_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ epcc.RequestExecutor = &epcc.Client{}
+
+func fakeHandleServices(rw http.ResponseWriter, req *http.Request) {
+	switch {
+	case req.URL.Path == "/v2/products/productID" && req.Method == "GET":
+		rw.WriteHeader(200)
+		rw.Write([]byte(`{"data":{"id":"productID","type":"product","sku":"CRN"}}`))
+	case req.URL.Path == "/v2/currencies" && req.Method == "GET":
+		rw.WriteHeader(200)
+		rw.Write([]byte(`{"data":[{"id":"currencyID","type":"currency","code":"GBP"}]}`))
+	case req.URL.Path == "/v2/flows/flowID" && req.Method == "DELETE":
+		rw.WriteHeader(204)
+	default:
+		rw.WriteHeader(404)
+	}
+}
+
+func TestClientServices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(fakeHandleServices))
+	defer server.Close()
+
+	client := epcc.NewClient(epcc.ClientOptions{
+		BaseURL:           server.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+	})
+
+	product, err := client.Products().Get("productID")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "CRN", product.Data.SKU)
+
+	currencies, err := client.Currencies().GetAll()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "GBP", currencies.Data[0].Code)
+
+	assert.Equal(t, nil, client.Flows().Delete("flowID"))
+
+	_, err = client.Accounts().Get("missingID")
+	assert.Equal(t, true, epcc.IsNotFound(err))
+}
@@ -1,7 +1,26 @@
 package epcc
 
+import "time"
+
 // Timestamps contains timestamp information
 type Timestamps struct {
 	CreatedAt string `json:"created_at,omitempty"`
 	UpdatedAt string `json:"updated_at,omitempty"`
 }
+
+// Created parses CreatedAt as an RFC3339 time. It returns the zero time if CreatedAt is empty.
+func (t Timestamps) Created() (time.Time, error) {
+	return parseTimestamp(t.CreatedAt)
+}
+
+// Updated parses UpdatedAt as an RFC3339 time. It returns the zero time if UpdatedAt is empty.
+func (t Timestamps) Updated() (time.Time, error) {
+	return parseTimestamp(t.UpdatedAt)
+}
+
+func parseTimestamp(timestamp string) (time.Time, error) {
+	if timestamp == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, timestamp)
+}
@@ -0,0 +1,55 @@
+package epcc_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimestamps(t *testing.T) {
+	tests := []struct {
+		timestamps epcc.Timestamps
+		created    time.Time
+		updated    time.Time
+		err        bool
+	}{
+		{
+			timestamps: epcc.Timestamps{CreatedAt: "2020-08-28T09:47:45+00:00", UpdatedAt: "2020-09-03T15:09:27+01:00"},
+			created:    time.Date(2020, 8, 28, 9, 47, 45, 0, time.UTC),
+			updated:    time.Date(2020, 9, 3, 14, 9, 27, 0, time.UTC),
+		},
+		{
+			timestamps: epcc.Timestamps{CreatedAt: "2020-09-01T15:48:10.050234331Z"},
+			created:    time.Date(2020, 9, 1, 15, 48, 10, 50234331, time.UTC),
+		},
+		{
+			timestamps: epcc.Timestamps{CreatedAt: "yesterday"},
+			err:        true,
+		},
+	}
+
+	for _, test := range tests {
+		created, err := test.timestamps.Created()
+		assert.Equal(t, test.err, err != nil)
+		assert.True(t, test.created.Equal(created))
+
+		updated, err := test.timestamps.Updated()
+		assert.Equal(t, nil, err)
+		assert.True(t, test.updated.Equal(updated))
+	}
+}
+
+func TestTimestampsRoundTrip(t *testing.T) {
+	rawJSON := `{"created_at":"2020-08-28T09:47:45+00:00","updated_at":"2020-09-01T15:48:10.050234395Z"}`
+
+	var timestamps epcc.Timestamps
+	err := json.Unmarshal([]byte(rawJSON), &timestamps)
+	assert.Equal(t, nil, err)
+
+	encoded, err := json.Marshal(timestamps)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, rawJSON, string(encoded))
+}
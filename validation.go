@@ -0,0 +1,130 @@
+package epcc
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// slugPattern matches the characters allowed in a product slug.
+var slugPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// maxDecimalPlaces is the most decimal places any ISO 4217 currency has.
+const maxDecimalPlaces = 4
+
+// Validate checks a product is complete and valid to be created.
+// It returns a *ValidationError listing every invalid field.
+func (p Product) Validate() error {
+	return p.validate(true)
+}
+
+// ValidateUpdate checks the fields which are set on a product are valid for an update.
+// It returns a *ValidationError listing every invalid field.
+func (p Product) ValidateUpdate() error {
+	return p.validate(false)
+}
+
+func (p Product) validate(create bool) error {
+	var v ValidationError
+
+	if create || p.Type != "" {
+		if p.Type != "product" {
+			v.add("data.type", "must be product")
+		}
+	}
+
+	if create {
+		required := []struct {
+			source string
+			value  string
+		}{
+			{"data.name", p.Name},
+			{"data.slug", p.Slug},
+			{"data.sku", p.SKU},
+		}
+		for _, field := range required {
+			if strings.TrimSpace(field.value) == "" {
+				v.add(field.source, "is required")
+			}
+		}
+	}
+
+	if p.Slug != "" && !slugPattern.MatchString(p.Slug) {
+		v.add("data.slug", "may only contain letters, numbers, hyphens and underscores")
+	}
+
+	for i, price := range p.Price {
+		source := "data.price." + strconv.Itoa(i)
+		if price.Amount < 0 {
+			v.add(source+".amount", "must not be negative")
+		}
+		if !iso4217[price.Currency] {
+			v.add(source+".currency", "must be an ISO 4217 currency code")
+		}
+	}
+
+	if p.Status != "" && !p.Status.Valid() {
+		v.add("data.status", "must be live or draft")
+	}
+	if p.CommodityType != "" && !p.CommodityType.Valid() {
+		v.add("data.commodity_type", "must be physical or digital")
+	}
+
+	return v.errOrNil()
+}
+
+// Validate checks a currency is complete and valid to be created.
+// It returns a *ValidationError listing every invalid field.
+func (c Currency) Validate() error {
+	return c.validate(true)
+}
+
+// ValidateUpdate checks the fields which are set on a currency are valid for an update.
+// It returns a *ValidationError listing every invalid field.
+func (c Currency) ValidateUpdate() error {
+	return c.validate(false)
+}
+
+func (c Currency) validate(create bool) error {
+	var v ValidationError
+
+	if create || c.Type != "" {
+		if c.Type != "currency" {
+			v.add("data.type", "must be currency")
+		}
+	}
+
+	if create || c.Code != "" {
+		if !iso4217[c.Code] {
+			v.add("data.code", "must be an ISO 4217 currency code")
+		}
+	}
+
+	if create || c.Format != "" {
+		if !strings.Contains(c.Format, pricePlaceholder) {
+			v.add("data.format", "must contain %s", pricePlaceholder)
+		}
+	}
+
+	if c.ExchangeRate < 0 {
+		v.add("data.exchange_rate", "must not be negative")
+	}
+
+	if c.DecimalPlaces < 0 || c.DecimalPlaces > maxDecimalPlaces {
+		v.add("data.decimal_places", "must be between 0 and %d", maxDecimalPlaces)
+	}
+
+	if create && c.DecimalPlaces > 0 && c.DecimalPoint == "" {
+		v.add("data.decimal_point", "is required when decimal_places is greater than 0")
+	}
+
+	return v.errOrNil()
+}
+
+// validatePayload validates a payload before it is sent when the client validates payloads.
+func validatePayload(client *Client, validate func() error) error {
+	if !client.ValidatePayloads {
+		return nil
+	}
+	return validate()
+}
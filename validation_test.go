@@ -0,0 +1,184 @@
+package epcc_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductValidate(t *testing.T) {
+	validProduct := epcc.Product{
+		Type:          "product",
+		Name:          "Blue Shirt",
+		Slug:          "blue-shirt_1",
+		SKU:           "BS1",
+		Status:        epcc.ProductStatusDraft,
+		CommodityType: epcc.CommodityTypePhysical,
+		Price: []epcc.ProductPrice{
+			{Amount: 1000, Currency: "GBP"},
+		},
+	}
+
+	invalidProduct := epcc.Product{
+		Type: "products",
+		Slug: "blue shirt",
+		Price: []epcc.ProductPrice{
+			{Amount: 1000, Currency: "GBP"},
+			{Amount: -1, Currency: "XYZ"},
+		},
+		Status: "archived",
+	}
+
+	tests := []struct {
+		product epcc.Product
+		create  error
+		update  error
+	}{
+		{validProduct, nil, nil},
+		{epcc.Product{Name: "Renamed"}, &epcc.ValidationError{
+			Errors: []epcc.ErrorDetail{
+				{Status: 422, Title: "Failed Validation", Detail: "data.type must be product", Source: "data.type"},
+				{Status: 422, Title: "Failed Validation", Detail: "data.slug is required", Source: "data.slug"},
+				{Status: 422, Title: "Failed Validation", Detail: "data.sku is required", Source: "data.sku"},
+			},
+		}, nil},
+		{invalidProduct, &epcc.ValidationError{
+			Errors: []epcc.ErrorDetail{
+				{Status: 422, Title: "Failed Validation", Detail: "data.type must be product", Source: "data.type"},
+				{Status: 422, Title: "Failed Validation", Detail: "data.name is required", Source: "data.name"},
+				{Status: 422, Title: "Failed Validation", Detail: "data.sku is required", Source: "data.sku"},
+				{Status: 422, Title: "Failed Validation", Detail: "data.slug may only contain letters, numbers, hyphens and underscores", Source: "data.slug"},
+				{Status: 422, Title: "Failed Validation", Detail: "data.price.1.amount must not be negative", Source: "data.price.1.amount"},
+				{Status: 422, Title: "Failed Validation", Detail: "data.price.1.currency must be an ISO 4217 currency code", Source: "data.price.1.currency"},
+				{Status: 422, Title: "Failed Validation", Detail: "data.status must be live or draft", Source: "data.status"},
+			},
+		}, &epcc.ValidationError{
+			Errors: []epcc.ErrorDetail{
+				{Status: 422, Title: "Failed Validation", Detail: "data.type must be product", Source: "data.type"},
+				{Status: 422, Title: "Failed Validation", Detail: "data.slug may only contain letters, numbers, hyphens and underscores", Source: "data.slug"},
+				{Status: 422, Title: "Failed Validation", Detail: "data.price.1.amount must not be negative", Source: "data.price.1.amount"},
+				{Status: 422, Title: "Failed Validation", Detail: "data.price.1.currency must be an ISO 4217 currency code", Source: "data.price.1.currency"},
+				{Status: 422, Title: "Failed Validation", Detail: "data.status must be live or draft", Source: "data.status"},
+			},
+		}},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.create, test.product.Validate())
+		assert.Equal(t, test.update, test.product.ValidateUpdate())
+	}
+}
+
+func TestCurrencyValidate(t *testing.T) {
+	validCurrency := epcc.Currency{
+		Type:              "currency",
+		Code:              "GBP",
+		ExchangeRate:      1,
+		Format:            "£{price}",
+		DecimalPoint:      ".",
+		ThousandSeparator: ",",
+		DecimalPlaces:     2,
+	}
+
+	invalidCurrency := epcc.Currency{
+		Type:          "currency",
+		Code:          "XYZ",
+		ExchangeRate:  -1,
+		Format:        "£",
+		DecimalPlaces: 5,
+	}
+
+	tests := []struct {
+		currency epcc.Currency
+		create   error
+		update   error
+	}{
+		{validCurrency, nil, nil},
+		{epcc.Currency{Type: "currency", Code: "JPY", Format: "¥{price}"}, nil, nil},
+		{epcc.Currency{Default: true}, &epcc.ValidationError{
+			Errors: []epcc.ErrorDetail{
+				{Status: 422, Title: "Failed Validation", Detail: "data.type must be currency", Source: "data.type"},
+				{Status: 422, Title: "Failed Validation", Detail: "data.code must be an ISO 4217 currency code", Source: "data.code"},
+				{Status: 422, Title: "Failed Validation", Detail: "data.format must contain {price}", Source: "data.format"},
+			},
+		}, nil},
+		{invalidCurrency, &epcc.ValidationError{
+			Errors: []epcc.ErrorDetail{
+				{Status: 422, Title: "Failed Validation", Detail: "data.code must be an ISO 4217 currency code", Source: "data.code"},
+				{Status: 422, Title: "Failed Validation", Detail: "data.format must contain {price}", Source: "data.format"},
+				{Status: 422, Title: "Failed Validation", Detail: "data.exchange_rate must not be negative", Source: "data.exchange_rate"},
+				{Status: 422, Title: "Failed Validation", Detail: "data.decimal_places must be between 0 and 4", Source: "data.decimal_places"},
+				{Status: 422, Title: "Failed Validation", Detail: "data.decimal_point is required when decimal_places is greater than 0", Source: "data.decimal_point"},
+			},
+		}, &epcc.ValidationError{
+			Errors: []epcc.ErrorDetail{
+				{Status: 422, Title: "Failed Validation", Detail: "data.code must be an ISO 4217 currency code", Source: "data.code"},
+				{Status: 422, Title: "Failed Validation", Detail: "data.format must contain {price}", Source: "data.format"},
+				{Status: 422, Title: "Failed Validation", Detail: "data.exchange_rate must not be negative", Source: "data.exchange_rate"},
+				{Status: 422, Title: "Failed Validation", Detail: "data.decimal_places must be between 0 and 4", Source: "data.decimal_places"},
+			},
+		}},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.create, test.currency.Validate())
+		assert.Equal(t, test.update, test.currency.ValidateUpdate())
+	}
+}
+
+func TestValidationErrorShape(t *testing.T) {
+	err := epcc.Product{Type: "product", Name: "Shirt", Slug: "shirt"}.Validate()
+	assert.Equal(t, "validation failed: data.sku is required", err.Error())
+
+	validationJSON, _ := json.Marshal(err)
+	var apiError epcc.APIError
+	json.Unmarshal(validationJSON, &apiError)
+	assert.Equal(t, err.(*epcc.ValidationError).Errors, apiError.Errors)
+}
+
+func TestErrorDetailStatus(t *testing.T) {
+	rawJSON := `{"errors":[{"status":400,"title":"Bad Request"},{"status":"404","title":"Not Found"},{"title":"Failed Validation"}]}`
+
+	var apiError epcc.APIError
+	err := json.Unmarshal([]byte(rawJSON), &apiError)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []epcc.ErrorDetail{
+		{Status: 400, Title: "Bad Request"},
+		{Status: 404, Title: "Not Found"},
+		{Title: "Failed Validation"},
+	}, apiError.Errors)
+}
+
+func TestValidatePayloads(t *testing.T) {
+	requests := 0
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+		rw.WriteHeader(500)
+	}))
+	options := epcc.ClientOptions{
+		BaseURL:           testServer.URL,
+		ClientTimeout:     10 * time.Second,
+		RetryLimitTimeout: 10 * time.Millisecond,
+		ValidatePayloads:  true,
+	}
+	client := epcc.NewClient(options)
+
+	_, err := epcc.Products.Create(client, &epcc.Product{Type: "product", Name: "Shirt", Slug: "shirt"})
+	assert.IsType(t, &epcc.ValidationError{}, err)
+
+	_, err = epcc.Products.Update(client, &epcc.Product{ID: "productID", Slug: "blue shirt"})
+	assert.IsType(t, &epcc.ValidationError{}, err)
+
+	_, err = epcc.Currencies.Create(client, &epcc.Currency{Type: "currency", Code: "GBP"})
+	assert.IsType(t, &epcc.ValidationError{}, err)
+
+	_, err = epcc.Currencies.Update(client, "currencyID", &epcc.Currency{DecimalPlaces: 9})
+	assert.IsType(t, &epcc.ValidationError{}, err)
+
+	assert.Equal(t, 0, requests)
+}
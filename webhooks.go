@@ -0,0 +1,246 @@
+package epcc
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// WebhookSecretHeader is the header EPCC uses to send the secret key of a webhook integration.
+const WebhookSecretHeader = "X-Moltin-Secret-Key"
+
+// defaultWebhookHistory is how many event IDs a WebhookHandler remembers to reject replays.
+const defaultWebhookHistory = 10000
+
+// maxWebhookBodySize is the largest event a WebhookHandler reads, larger requests are rejected.
+const maxWebhookBodySize = 1 << 20
+
+// WebhookEvent is the envelope of an event delivered by a webhook integration.
+type WebhookEvent struct {
+	ID          string             `json:"id"`
+	TriggeredBy IntegrationEvent   `json:"triggered_by"`
+	Attempt     int                `json:"attempt"`
+	Integration WebhookIntegration `json:"integration"`
+	Resources   json.RawMessage    `json:"resources"`
+
+	strictEnums bool // strictEnums is set by a WebhookHandler in strict mode.
+}
+
+// WebhookIntegration identifies the integration which delivered an event.
+type WebhookIntegration struct {
+	ID              string `json:"id"`
+	IntegrationType string `json:"integration_type"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+}
+
+// UnmarshalJSON decodes an event. EPCC sends resources as a string of encoded json,
+// which is decoded so Resources always holds the json object itself.
+func (e *WebhookEvent) UnmarshalJSON(data []byte) error {
+	type webhookEvent WebhookEvent
+	var decoded webhookEvent
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	if len(decoded.Resources) > 0 && decoded.Resources[0] == '"' {
+		var resources string
+		if err := json.Unmarshal(decoded.Resources, &resources); err != nil {
+			return err
+		}
+		decoded.Resources = json.RawMessage(resources)
+	}
+
+	*e = WebhookEvent(decoded)
+	return nil
+}
+
+// Product decodes the product an event was triggered by. When the event was received by a
+// WebhookHandler with StrictEnums set, a product with unknown enum values is an error.
+func (e WebhookEvent) Product() (*Product, error) {
+	var resources ProductData
+	if err := e.decodeResources(&resources); err != nil {
+		return nil, err
+	}
+	if e.strictEnums {
+		if err := resources.Data.checkEnums(); err != nil {
+			return nil, err
+		}
+	}
+	return &resources.Data, nil
+}
+
+// Currency decodes the currency an event was triggered by.
+func (e WebhookEvent) Currency() (*Currency, error) {
+	var resources CurrencyData
+	if err := e.decodeResources(&resources); err != nil {
+		return nil, err
+	}
+	return &resources.Data, nil
+}
+
+// Order decodes the order an event was triggered by.
+func (e WebhookEvent) Order() (*Order, error) {
+	var resources OrderData
+	if err := e.decodeResources(&resources); err != nil {
+		return nil, err
+	}
+	return &resources.Data, nil
+}
+
+func (e WebhookEvent) decodeResources(v interface{}) error {
+	if len(e.Resources) == 0 {
+		return errors.New("error event has no resources")
+	}
+	return json.Unmarshal(e.Resources, v)
+}
+
+// WebhookHandler is an http.Handler which receives events from a webhook integration.
+//
+// Requests without the secret key of the integration are rejected with 401 Unauthorized,
+// and bodies larger than 1MB with 413 Request Entity Too Large.
+// Each event is dispatched to the callback registered for its type. An event ID which has
+// already been handled is acknowledged without being dispatched again, while a delivery of
+// an event which is still being handled is rejected with 409 Conflict so EPCC retries it.
+// If a callback returns an error the handler responds 500 Internal Server Error so EPCC
+// retries the delivery.
+type WebhookHandler struct {
+	StrictEnums bool // StrictEnums rejects products with unknown enum values, like the ClientOptions field.
+
+	secretKey  string
+	mu         sync.Mutex
+	callbacks  map[IntegrationEvent]func(*WebhookEvent) error
+	inProgress map[string]bool // inProgress holds the IDs of events whose callback is running.
+	seen       map[string]bool // seen holds the IDs of events which were handled, in history.
+	history    []string
+	next       int
+}
+
+// NewWebhookHandler creates a WebhookHandler for an integration with the given secret key.
+func NewWebhookHandler(secretKey string) *WebhookHandler {
+	return &WebhookHandler{
+		secretKey:  secretKey,
+		callbacks:  make(map[IntegrationEvent]func(*WebhookEvent) error),
+		inProgress: make(map[string]bool),
+		seen:       make(map[string]bool),
+		history:    make([]string, defaultWebhookHistory),
+	}
+}
+
+// Handle registers the callback for an event, replacing any previous callback.
+func (h *WebhookHandler) Handle(event IntegrationEvent, callback func(*WebhookEvent) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.callbacks[event] = callback
+}
+
+// HandleProduct registers a callback for an event triggered by a product.
+func (h *WebhookHandler) HandleProduct(event IntegrationEvent, callback func(*WebhookEvent, *Product) error) {
+	h.Handle(event, func(e *WebhookEvent) error {
+		product, err := e.Product()
+		if err != nil {
+			return err
+		}
+		return callback(e, product)
+	})
+}
+
+// HandleCurrency registers a callback for an event triggered by a currency.
+func (h *WebhookHandler) HandleCurrency(event IntegrationEvent, callback func(*WebhookEvent, *Currency) error) {
+	h.Handle(event, func(e *WebhookEvent) error {
+		currency, err := e.Currency()
+		if err != nil {
+			return err
+		}
+		return callback(e, currency)
+	})
+}
+
+// HandleOrder registers a callback for an event triggered by an order.
+func (h *WebhookHandler) HandleOrder(event IntegrationEvent, callback func(*WebhookEvent, *Order) error) {
+	h.Handle(event, func(e *WebhookEvent) error {
+		order, err := e.Order()
+		if err != nil {
+			return err
+		}
+		return callback(e, order)
+	})
+}
+
+// ServeHTTP verifies, decodes and dispatches a single event.
+func (h *WebhookHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.Method != "POST" {
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	secretKey := req.Header.Get(WebhookSecretHeader)
+	if h.secretKey == "" || subtle.ConstantTimeCompare([]byte(secretKey), []byte(h.secretKey)) != 1 {
+		rw.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var buffer bytes.Buffer
+	if _, err := buffer.ReadFrom(http.MaxBytesReader(rw, req.Body, maxWebhookBodySize)); err != nil {
+		if buffer.Len() >= maxWebhookBodySize {
+			rw.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(buffer.Bytes(), &event); err != nil || event.ID == "" {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	event.strictEnums = h.StrictEnums
+
+	h.mu.Lock()
+	callback, ok := h.callbacks[event.TriggeredBy]
+	if !ok || h.seen[event.ID] {
+		h.mu.Unlock()
+		rw.WriteHeader(http.StatusOK)
+		return
+	}
+	if h.inProgress[event.ID] {
+		h.mu.Unlock()
+		rw.WriteHeader(http.StatusConflict)
+		return
+	}
+	h.inProgress[event.ID] = true
+	h.mu.Unlock()
+
+	err := callback(&event)
+
+	// The event is only remembered once it has been handled, so a failed event
+	// takes no place in the history and its retried delivery is dispatched.
+	h.mu.Lock()
+	delete(h.inProgress, event.ID)
+	if err == nil {
+		h.remember(event.ID)
+	}
+	h.mu.Unlock()
+
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	rw.WriteHeader(http.StatusOK)
+}
+
+// remember records an event ID, forgetting the oldest ID once the history is full.
+// It must be called with h.mu held.
+func (h *WebhookHandler) remember(eventID string) {
+	if oldest := h.history[h.next]; oldest != "" {
+		delete(h.seen, oldest)
+	}
+
+	h.history[h.next] = eventID
+	h.next = (h.next + 1) % len(h.history)
+	h.seen[eventID] = true
+}
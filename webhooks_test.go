@@ -0,0 +1,173 @@
+package epcc_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Rosalita/go-epcc-client"
+	"github.com/stretchr/testify/assert"
+)
+
+const productUpdatedEvent = `{
+	"id":"a9b62b3f-9a5a-4a8d-bd4f-8ad51e7a1f15",
+	"triggered_by":"product.updated",
+	"attempt":1,
+	"integration":{
+		"id":"8c3f0dbe-06e1-4d2e-9d7b-2a4b5b3b3e91",
+		"integration_type":"webhook",
+		"name":"Product notifications"
+	},
+	"resources":"{\"data\":{\"type\":\"product\",\"id\":\"78ee7c20-df84-435d-bb1d-531e3537c4dc\",\"name\":\"Origami Frog\",\"sku\":\"FRG\"}}"
+}`
+
+const orderCreatedEvent = `{
+	"id":"0e3b5a16-8f0e-4c35-a7c2-6b1fb5d1c2a4",
+	"triggered_by":"order.created",
+	"attempt":1,
+	"integration":{
+		"id":"8c3f0dbe-06e1-4d2e-9d7b-2a4b5b3b3e91",
+		"integration_type":"webhook",
+		"name":"Order notifications"
+	},
+	"resources":{
+		"data":{
+			"type":"order",
+			"id":"f5c0cf3e-4b8e-4f6e-96b2-6e5b6b0b8f7d",
+			"status":"incomplete",
+			"payment":"unpaid",
+			"customer":{"name":"Ron Swanson","email":"ron@swanson.com"},
+			"meta":{
+				"display_price":{
+					"with_tax":{"amount":1000,"currency":"USD","formatted":"$10.00"}
+				}
+			}
+		}
+	}
+}`
+
+func sendWebhook(handler http.Handler, method string, secretKey string, body string) int {
+	req := httptest.NewRequest(method, "/webhooks", strings.NewReader(body))
+	if secretKey != "" {
+		req.Header.Set(epcc.WebhookSecretHeader, secretKey)
+	}
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+	return rw.Code
+}
+
+func TestWebhookHandler(t *testing.T) {
+	handler := epcc.NewWebhookHandler("secret")
+
+	var products []*epcc.Product
+	failNext := true
+	handler.HandleProduct(epcc.EventProductUpdated, func(event *epcc.WebhookEvent, product *epcc.Product) error {
+		if failNext {
+			failNext = false
+			return errors.New("database unavailable")
+		}
+		products = append(products, product)
+		return nil
+	})
+
+	var orders []*epcc.Order
+	handler.HandleOrder(epcc.EventOrderCreated, func(event *epcc.WebhookEvent, order *epcc.Order) error {
+		assert.Equal(t, "Order notifications", event.Integration.Name)
+		orders = append(orders, order)
+		return nil
+	})
+
+	tests := []struct {
+		method     string
+		secretKey  string
+		body       string
+		statusCode int
+	}{
+		{"GET", "secret", "", 405},
+		{"POST", "", productUpdatedEvent, 401},
+		{"POST", "wrong", productUpdatedEvent, 401},
+		{"POST", "secret", "not json", 400},
+		{"POST", "secret", `{"triggered_by":"product.updated"}`, 400},
+		// The first delivery fails, so the retry is dispatched and later replays are not.
+		{"POST", "secret", productUpdatedEvent, 500},
+		{"POST", "secret", productUpdatedEvent, 200},
+		{"POST", "secret", productUpdatedEvent, 200},
+		{"POST", "secret", orderCreatedEvent, 200},
+		{"POST", "secret", `{"id":"unhandled","triggered_by":"customer.created"}`, 200},
+	}
+
+	for _, test := range tests {
+		statusCode := sendWebhook(handler, test.method, test.secretKey, test.body)
+		assert.Equal(t, test.statusCode, statusCode)
+	}
+
+	assert.Equal(t, 1, len(products))
+	assert.Equal(t, "Origami Frog", products[0].Name)
+	assert.Equal(t, "FRG", products[0].SKU)
+
+	expectedOrder := epcc.Order{
+		ID:       "f5c0cf3e-4b8e-4f6e-96b2-6e5b6b0b8f7d",
+		Type:     "order",
+		Status:   "incomplete",
+		Payment:  "unpaid",
+		Customer: epcc.OrderCustomer{Name: "Ron Swanson", Email: "ron@swanson.com"},
+		Meta: epcc.OrderMeta{
+			DisplayPrice: epcc.OrderDisplayPrice{
+				WithTax: epcc.DisplayPrice{Amount: 1000, Currency: "USD", Formatted: "$10.00"},
+			},
+		},
+	}
+	assert.Equal(t, []*epcc.Order{&expectedOrder}, orders)
+}
+
+func TestWebhookHandlerWithoutSecretKey(t *testing.T) {
+	handler := epcc.NewWebhookHandler("")
+	handler.Handle(epcc.EventProductUpdated, func(event *epcc.WebhookEvent) error {
+		return nil
+	})
+
+	assert.Equal(t, 401, sendWebhook(handler, "POST", "", productUpdatedEvent))
+}
+
+func TestWebhookHandlerConcurrentDelivery(t *testing.T) {
+	handler := epcc.NewWebhookHandler("secret")
+
+	started := make(chan bool)
+	release := make(chan error)
+	calls := 0
+	handler.Handle(epcc.EventProductUpdated, func(event *epcc.WebhookEvent) error {
+		calls++
+		started <- true
+		return <-release
+	})
+
+	// A duplicate delivered while the first is being handled is not acknowledged.
+	first := make(chan int)
+	go func() { first <- sendWebhook(handler, "POST", "secret", productUpdatedEvent) }()
+	<-started
+	assert.Equal(t, 409, sendWebhook(handler, "POST", "secret", productUpdatedEvent))
+
+	// The first delivery fails, so the event is dispatched again when it is retried.
+	release <- errors.New("database unavailable")
+	assert.Equal(t, 500, <-first)
+
+	go func() { first <- sendWebhook(handler, "POST", "secret", productUpdatedEvent) }()
+	<-started
+	release <- nil
+	assert.Equal(t, 200, <-first)
+
+	assert.Equal(t, 200, sendWebhook(handler, "POST", "secret", productUpdatedEvent))
+	assert.Equal(t, 2, calls)
+}
+
+func TestWebhookHandlerBodyTooLarge(t *testing.T) {
+	handler := epcc.NewWebhookHandler("secret")
+	handler.Handle(epcc.EventProductUpdated, func(event *epcc.WebhookEvent) error {
+		return nil
+	})
+
+	body := `{"id":"large","triggered_by":"product.updated","resources":"` + strings.Repeat("a", 1<<20) + `"}`
+	assert.Equal(t, 413, sendWebhook(handler, "POST", "secret", body))
+}